@@ -0,0 +1,51 @@
+//Command dfagen compiles a regular expression into a dependency free Go validator
+//
+//It is meant to be run from a go:generate directive, for example
+//
+//	//go:generate go run github.com/ChristopherCamara/finiteAutomata/cmd/dfagen -regex "(a|b)*c" -pkg main -func isValid -o valid_gen.go
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/ChristopherCamara/finiteAutomata/dfa"
+	regexparser "github.com/ChristopherCamara/finiteAutomata/regexParser"
+)
+
+func main() {
+	regex := flag.String("regex", "", "regular expression to compile")
+	pkg := flag.String("pkg", "", "package name of the generated file, defaults to $GOPACKAGE")
+	funcName := flag.String("func", "match", "name of the generated function")
+	output := flag.String("o", "", "file to write, defaults to standard output")
+	flag.Parse()
+	if *pkg == "" {
+		*pkg = os.Getenv("GOPACKAGE")
+	}
+	if *pkg == "" {
+		log.Fatal("dfagen: -pkg is required outside of go generate")
+	}
+	parser := new(regexparser.RegexParser)
+	compiled := dfa.FromNFA(parser.ParseToNFA(*regex))
+	compiled.Minimize()
+	if *output == "" {
+		if err := compiled.GenerateGo(os.Stdout, *pkg, *funcName); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	file, err := os.Create(*output)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = compiled.GenerateGo(file, *pkg, *funcName)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		//never leave a truncated file behind for the build to pick up
+		os.Remove(*output)
+		log.Fatal(err)
+	}
+}
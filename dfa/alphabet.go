@@ -0,0 +1,116 @@
+package dfa
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ChristopherCamara/finiteAutomata/internal/stringArray"
+)
+
+//SortedAlphabet returns a sorted copy of the Alphabet of a DFA
+func (dfa *DFA) SortedAlphabet() []string {
+	alphabet := append([]string(nil), dfa.Alphabet...)
+	sort.Strings(alphabet)
+	return alphabet
+}
+
+//ExtendAlphabet adds symbols that are not in the Alphabet yet and completes the DFA
+//
+//Every state without a transition on some symbol of the Alphabet gets one to
+//a sink state, which is only added when it is needed. Returns the sink state,
+//-1 if the DFA was already complete. The language does not change, Minimize
+//drops the sink state again
+func (dfa *DFA) ExtendAlphabet(symbols ...string) int {
+	//FromNFA keeps the Alphabet of the NFA, appending in place could write into it
+	alphabet := append([]string(nil), dfa.Alphabet...)
+	for _, symbol := range symbols {
+		if stringArray.IndexOf(symbol, alphabet) == -1 {
+			alphabet = append(alphabet, symbol)
+		}
+	}
+	dfa.Alphabet = alphabet
+	sinkState := -1
+	for _, state := range dfa.States {
+		for _, symbol := range dfa.Alphabet {
+			if _, exists := dfa.Transitions[state][symbol]; exists {
+				continue
+			}
+			if sinkState == -1 {
+				sinkState = dfa.AddState(false, false)
+				for _, sinkSymbol := range dfa.Alphabet {
+					dfa.AddTransition(sinkState, sinkSymbol, sinkState)
+				}
+			}
+			dfa.AddTransition(state, symbol, sinkState)
+		}
+	}
+	return sinkState
+}
+
+//RestrictAlphabet keeps only the given symbols, transitions on any other symbol are removed
+func (dfa *DFA) RestrictAlphabet(symbols ...string) {
+	alphabet := make([]string, 0, len(symbols))
+	for _, symbol := range dfa.Alphabet {
+		if stringArray.IndexOf(symbol, symbols) != -1 {
+			alphabet = append(alphabet, symbol)
+		}
+	}
+	dfa.Alphabet = alphabet
+	for _, state := range dfa.States {
+		for symbol := range dfa.Transitions[state] {
+			if stringArray.IndexOf(symbol, symbols) == -1 {
+				delete(dfa.Transitions[state], symbol)
+			}
+		}
+	}
+}
+
+//RenameSymbols replaces every symbol found in renames by its new name
+//
+//Symbols missing from renames keep their name. If two symbols leaving the
+//same state are renamed to the same name but lead to different states the
+//result would not be deterministic, an error is returned and the DFA is left
+//untouched
+func (dfa *DFA) RenameSymbols(renames map[string]string) error {
+	rename := func(symbol string) string {
+		if renamed, exists := renames[symbol]; exists {
+			return renamed
+		}
+		return symbol
+	}
+	transitions := make(map[int]map[string]int, len(dfa.Transitions))
+	for _, state := range dfa.States {
+		transitions[state] = make(map[string]int, len(dfa.Transitions[state]))
+		for _, symbol := range dfa.sortedSymbols(state) {
+			targetState := dfa.Transitions[state][symbol]
+			if existingState, exists := transitions[state][rename(symbol)]; exists && existingState != targetState {
+				return fmt.Errorf("renaming to %q makes state %d nondeterministic", rename(symbol), state)
+			}
+			transitions[state][rename(symbol)] = targetState
+		}
+	}
+	alphabet := make([]string, 0, len(dfa.Alphabet))
+	for _, symbol := range dfa.Alphabet {
+		if stringArray.IndexOf(rename(symbol), alphabet) == -1 {
+			alphabet = append(alphabet, rename(symbol))
+		}
+	}
+	dfa.Alphabet = alphabet
+	dfa.Transitions = transitions
+	return nil
+}
+
+//UnifyAlphabets extends every DFA with the symbols of all the others, completing each with a sink state
+func UnifyAlphabets(dfas ...*DFA) {
+	alphabet := make([]string, 0)
+	for _, current := range dfas {
+		for _, symbol := range current.Alphabet {
+			if stringArray.IndexOf(symbol, alphabet) == -1 {
+				alphabet = append(alphabet, symbol)
+			}
+		}
+	}
+	for _, current := range dfas {
+		current.ExtendAlphabet(alphabet...)
+	}
+}
@@ -0,0 +1,47 @@
+package dfa
+
+import (
+	"reflect"
+	"testing"
+
+	regexparser "github.com/ChristopherCamara/finiteAutomata/regexParser"
+)
+
+//a RegexParser keeps appending to the Alphabet FromNFA handed to earlier DFAs
+func TestExtendAlphabetDoesNotShareAlphabet(t *testing.T) {
+	parser := new(regexparser.RegexParser)
+	FromNFA(parser.ParseToNFA("ab"))
+	d2 := FromNFA(parser.ParseToNFA("abc"))
+	d2.ExtendAlphabet("x")
+	FromNFA(parser.ParseToNFA("abcd"))
+	if expected := []string{"a", "b", "c", "x"}; !reflect.DeepEqual(d2.Alphabet, expected) {
+		t.Errorf("expected alphabet %v, got %v", expected, d2.Alphabet)
+	}
+}
+
+func TestExtendAlphabetSinkState(t *testing.T) {
+	parser := new(regexparser.RegexParser)
+	extended := FromNFA(parser.ParseToNFA("(ab)*"))
+	sinkState := extended.ExtendAlphabet("c")
+	if sinkState == -1 {
+		t.Fatal("expected a sink state")
+	}
+	for _, state := range extended.States {
+		for _, symbol := range extended.Alphabet {
+			if _, exists := extended.Transitions[state][symbol]; !exists {
+				t.Errorf("state %d has no transition on %s", state, symbol)
+			}
+		}
+	}
+	if again := extended.ExtendAlphabet("a", "c"); again != -1 {
+		t.Errorf("a complete DFA got sink state %d", again)
+	}
+	//the sink state must not change the minimal DFA of the language
+	expected := FromNFA(parser.ParseToNFA("(ab)*"))
+	expected.Alphabet = append(expected.SortedAlphabet(), "c")
+	expected.Minimize()
+	extended.Minimize()
+	if !Isomorphic(extended, expected) {
+		t.Error("minimizing after ExtendAlphabet keeps the sink state")
+	}
+}
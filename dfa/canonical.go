@@ -0,0 +1,84 @@
+package dfa
+
+import (
+	"sort"
+
+	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+)
+
+//Canonical returns a copy of a DFA with its states renumbered in breadth first order
+//
+//States are visited from the start state following symbols in sorted order,
+//states that cannot be reached are dropped. Two DFAs that only differ in how
+//their states are numbered have identical canonical forms, so do two minimal
+//DFAs for the same language
+func (dfa *DFA) Canonical() *DFA {
+	canonicalDFA := New()
+	canonicalDFA.Alphabet = append(canonicalDFA.Alphabet, dfa.Alphabet...)
+	sort.Strings(canonicalDFA.Alphabet)
+	if len(dfa.StartStates) == 0 {
+		return canonicalDFA
+	}
+	symbols := dfa.symbols()
+	stateMappings := make(map[int]int)
+	addState := func(state int, isStart bool) int {
+		stateMappings[state] = canonicalDFA.AddState(isStart, intArray.IndexOf(state, dfa.AcceptStates) != -1)
+		if labels, exists := dfa.AcceptLabels[state]; exists {
+			canonicalDFA.AcceptLabels[stateMappings[state]] = append([]int(nil), labels...)
+		}
+		return stateMappings[state]
+	}
+	queue := []int{dfa.StartStates[0]}
+	addState(dfa.StartStates[0], true)
+	for len(queue) != 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for _, symbol := range symbols {
+			targetState, exists := dfa.Transitions[state][symbol]
+			if !exists {
+				continue
+			}
+			if _, visited := stateMappings[targetState]; !visited {
+				addState(targetState, false)
+				queue = append(queue, targetState)
+			}
+			canonicalDFA.AddTransition(stateMappings[state], symbol, stateMappings[targetState])
+		}
+	}
+	return canonicalDFA
+}
+
+//Isomorphic reports whether two DFAs are the same up to the numbering of their reachable states
+func Isomorphic(a, b *DFA) bool {
+	first, second := a.Canonical(), b.Canonical()
+	if len(first.States) != len(second.States) || len(first.AcceptStates) != len(second.AcceptStates) {
+		return false
+	}
+	if len(first.Alphabet) != len(second.Alphabet) {
+		return false
+	}
+	for i, symbol := range first.Alphabet {
+		if second.Alphabet[i] != symbol {
+			return false
+		}
+	}
+	for i, acceptState := range first.AcceptStates {
+		if second.AcceptStates[i] != acceptState {
+			return false
+		}
+		if !intArray.Equals(first.AcceptLabels[acceptState], second.AcceptLabels[acceptState]) {
+			return false
+		}
+	}
+	for _, state := range first.States {
+		if len(first.Transitions[state]) != len(second.Transitions[state]) {
+			return false
+		}
+		for symbol, targetState := range first.Transitions[state] {
+			if otherState, exists := second.Transitions[state][symbol]; !exists || otherState != targetState {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package dfa
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	regexparser "github.com/ChristopherCamara/finiteAutomata/regexParser"
+)
+
+//a complete DFA and a partial one for the same language must minimize to the same canonical form
+func TestCanonicalCompleteAndPartial(t *testing.T) {
+	for _, regex := range []string{"a*", "ab", "b|ab", "(a|b)*abb", "(ab|a)*"} {
+		parser := new(regexparser.RegexParser)
+		partial := FromNFA(parser.ParseToNFA(regex))
+		complete := FromNFA(parser.ParseToNFA(regex))
+		if complete.ExtendAlphabet(partial.Alphabet...) == -1 {
+			//some regexes give a complete DFA already, a new symbol makes the sink state explicit
+			partial.Alphabet = append(partial.SortedAlphabet(), "z")
+			complete.ExtendAlphabet("z")
+		}
+		partial.Minimize()
+		complete.Minimize()
+		if !Isomorphic(partial, complete) {
+			t.Errorf("%s: minimal DFAs of the complete and partial input are not isomorphic", regex)
+		}
+		partialJSON, err := json.Marshal(partial.Canonical())
+		if err != nil {
+			t.Fatal(err)
+		}
+		completeJSON, err := json.Marshal(complete.Canonical())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(partialJSON, completeJSON) {
+			t.Errorf("%s: canonical forms differ\n%s\n%s", regex, partialJSON, completeJSON)
+		}
+	}
+}
+
+func TestCanonicalRenumbers(t *testing.T) {
+	first := New()
+	firstStart := first.AddState(true, false)
+	firstAccept := first.AddState(false, true)
+	first.Alphabet = []string{"a", "b"}
+	first.AddTransition(firstStart, "a", firstAccept)
+	first.AddTransition(firstAccept, "b", firstStart)
+	second := New()
+	secondAccept := second.AddState(false, true)
+	secondStart := second.AddState(true, false)
+	second.Alphabet = []string{"b", "a"}
+	second.AddTransition(secondStart, "a", secondAccept)
+	second.AddTransition(secondAccept, "b", secondStart)
+	if !Isomorphic(first, second) {
+		t.Error("DFAs that only differ in their numbering are not isomorphic")
+	}
+	second.AddTransition(secondAccept, "a", secondAccept)
+	if Isomorphic(first, second) {
+		t.Error("DFAs with different transitions are isomorphic")
+	}
+}
@@ -1,15 +1,14 @@
 package dfa
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"sort"
 	"strconv"
-	"strings"
 
 	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+	"github.com/ChristopherCamara/finiteAutomata/internal/stringArray"
 	"github.com/ChristopherCamara/finiteAutomata/nfa"
-	"github.com/goccy/go-graphviz"
-	"github.com/goccy/go-graphviz/cgraph"
 )
 
 //DFA deterministic finite automata struct definition
@@ -20,11 +19,7 @@ type DFA struct {
 	StartStates  []int
 	AcceptStates []int
 	Transitions  map[int]map[string]int
-}
-
-type edge struct {
-	edge  *cgraph.Edge
-	label string
+	AcceptLabels map[int][]int
 }
 
 //New returns ready to use *DFA
@@ -36,72 +31,28 @@ func New() *DFA {
 	newDFA.StartStates = make([]int, 0)
 	newDFA.AcceptStates = make([]int, 0)
 	newDFA.Transitions = make(map[int]map[string]int, 0)
+	newDFA.AcceptLabels = make(map[int][]int, 0)
 	return newDFA
 }
 
-//SaveGraphviz image file
-func (dfa *DFA) SaveGraphviz(fileName string) {
-	fileName = strings.ReplaceAll(fileName, "*", "star")
-	fileName = strings.ReplaceAll(fileName, "|", " or ")
-	g := graphviz.New()
-	graph, err := g.Graph()
-	if err != nil {
-		log.Fatal(err)
-	}
-	graph.SetLayout("dot")
-	graph.SetRankDir(cgraph.LRRank)
-	defer func() {
-		if err := graph.Close(); err != nil {
-			log.Fatal(err)
-		}
-	}()
-	nodeMappings := make(map[int]*cgraph.Node)
-	edgeMappings := make(map[int]map[int]*edge)
-	for _, currentState := range dfa.States {
-		newNode, err := graph.CreateNode(strconv.Itoa(currentState))
-		if err != nil {
-			log.Panic(err)
-		}
-		if intArray.IndexOf(currentState, dfa.StartStates) != -1 {
-			nilNode, err := graph.CreateNode("")
-			if err != nil {
-				log.Panic(err)
-			}
-			nilNode.SetShape(cgraph.NoneShape)
-			_, err = graph.CreateEdge("", nilNode, newNode)
-			if err != nil {
-				log.Panic(err)
-			}
-		}
-		if intArray.IndexOf(currentState, dfa.AcceptStates) != -1 {
-			newNode.SetShape(cgraph.DoubleCircleShape)
-		} else {
-			newNode.SetShape(cgraph.CircleShape)
+//clone returns a deep copy of a DFA
+func (dfa *DFA) clone() *DFA {
+	newDFA := New()
+	newDFA.nextState = dfa.nextState
+	newDFA.Alphabet = append(newDFA.Alphabet, dfa.Alphabet...)
+	newDFA.States = append(newDFA.States, dfa.States...)
+	newDFA.StartStates = append(newDFA.StartStates, dfa.StartStates...)
+	newDFA.AcceptStates = append(newDFA.AcceptStates, dfa.AcceptStates...)
+	for state, transitions := range dfa.Transitions {
+		newDFA.Transitions[state] = make(map[string]int, len(transitions))
+		for symbol, targetState := range transitions {
+			newDFA.Transitions[state][symbol] = targetState
 		}
-		nodeMappings[currentState] = newNode
 	}
-	for _, currentState := range dfa.States {
-		for symbol, transitionState := range dfa.Transitions[currentState] {
-			newEdge, err := graph.CreateEdge("", nodeMappings[currentState], nodeMappings[transitionState])
-			if err != nil {
-				log.Panic(err)
-			}
-			newEdge.SetLabel(symbol)
-			if edgeMappings[currentState] == nil {
-				edgeMappings[currentState] = make(map[int]*edge)
-				edgeMappings[currentState][transitionState] = &edge{edge: newEdge, label: symbol}
-			} else if currentEdge, exists := edgeMappings[currentState][transitionState]; exists {
-				currentEdge.label = currentEdge.label + "," + symbol
-				currentEdge.edge.SetLabel(currentEdge.label)
-			} else {
-				edgeMappings[currentState][transitionState] = &edge{edge: newEdge, label: symbol}
-			}
-		}
-	}
-	err = g.RenderFilename(graph, graphviz.PNG, fileName+".png")
-	if err != nil {
-		log.Fatal(err)
+	for state, labels := range dfa.AcceptLabels {
+		newDFA.AcceptLabels[state] = append([]int(nil), labels...)
 	}
+	return newDFA
 }
 
 //AddState to a DFA
@@ -124,6 +75,16 @@ func (dfa *DFA) AddTransition(sourceState int, symbol string, targetState int) {
 	dfa.Transitions[sourceState][symbol] = targetState
 }
 
+//sortedSymbols of the transitions leaving state
+func (dfa *DFA) sortedSymbols(state int) []string {
+	symbols := make([]string, 0, len(dfa.Transitions[state]))
+	for symbol := range dfa.Transitions[state] {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
 //Print out DFA information
 func (dfa *DFA) Print() {
 	fmt.Println("~~~DFA~~~")
@@ -132,17 +93,42 @@ func (dfa *DFA) Print() {
 	for _, state := range dfa.States {
 		fmt.Printf("state %d:\n", state)
 		if dfa.Transitions[state] != nil {
-			for symbol, state := range dfa.Transitions[state] {
-				fmt.Printf("\t%s -> %d\n", symbol, state)
+			for _, symbol := range dfa.sortedSymbols(state) {
+				fmt.Printf("\t%s -> %d\n", symbol, dfa.Transitions[state][symbol])
 			}
 		}
 	}
 	fmt.Print("accept states: ")
 	intArray.Print(dfa.AcceptStates)
+	for _, state := range dfa.AcceptStates {
+		if len(dfa.AcceptLabels[state]) != 0 {
+			fmt.Printf("state %d labels: ", state)
+			intArray.Print(dfa.AcceptLabels[state])
+		}
+	}
 }
 
 //Reverse a DFA, which is one of the operations it is closed under
 func (dfa *DFA) Reverse() *DFA {
+	reverseDFA, _ := dfa.reverse(context.Background(), Limits{})
+	return reverseDFA
+}
+
+func (dfa *DFA) reverse(ctx context.Context, limits Limits) (*DFA, error) {
+	reverseDFA, err := fromNFA(ctx, dfa.reverseNFA(), limits, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := reverseDFA.minimize(ctx, nil); err != nil {
+		return nil, err
+	}
+	return reverseDFA, nil
+}
+
+//reverseNFA flips every transition of a DFA and swaps its start and accept states
+//
+//The result may have several start states, fromNFA starts from the closure of all of them
+func (dfa *DFA) reverseNFA() *nfa.NFA {
 	NFA := nfa.New()
 	NFA.Alphabet = dfa.Alphabet
 	stateMappings := make(map[int]int)
@@ -156,58 +142,65 @@ func (dfa *DFA) Reverse() *DFA {
 		}
 	}
 	for _, state := range dfa.States {
-		for symbol, transitionState := range dfa.Transitions[state] {
+		for _, symbol := range dfa.sortedSymbols(state) {
+			transitionState := dfa.Transitions[state][symbol]
 			if _, exists := NFA.Transitions[stateMappings[transitionState]]; !exists {
 				NFA.Transitions[stateMappings[transitionState]] = make(map[string][]int, 0)
 			}
 			NFA.Transitions[stateMappings[transitionState]][symbol] = append(NFA.Transitions[stateMappings[transitionState]][symbol], stateMappings[state])
 		}
 	}
-	newStart := NFA.AddState(false, false)
-	for _, startState := range NFA.StartStates {
-		NFA.AddEpsilonTransition(newStart, startState)
-	}
-	NFA.StartStates = []int{newStart}
-	NFA.SaveGraphviz("test")
-	reverseDFA := FromNFA(NFA)
-	reverseDFA.Minimize()
-	return reverseDFA
+	return NFA
 }
 
-func (dfa *DFA) distinguishable(first, second int, otherPartition []int) bool {
-	for symbol, targetState := range dfa.Transitions[first] {
-		if _, exists := dfa.Transitions[second][symbol]; !exists {
-			continue
-		}
-		if intArray.IndexOf(targetState, otherPartition) != -1 && intArray.IndexOf(dfa.Transitions[second][symbol], otherPartition) == -1 {
-			return true
-		}
-		if intArray.IndexOf(targetState, otherPartition) == -1 && intArray.IndexOf(dfa.Transitions[second][symbol], otherPartition) != -1 {
-			return true
-		}
+//acceptKey groups states that may share a partition before any refinement
+func (dfa *DFA) acceptKey(state int) string {
+	if intArray.IndexOf(state, dfa.AcceptStates) == -1 {
+		return ""
+	}
+	key := "accept"
+	for _, label := range dfa.AcceptLabels[state] {
+		key += "," + strconv.Itoa(label)
 	}
-	for symbol, targetState := range dfa.Transitions[second] {
-		if _, exists := dfa.Transitions[first][symbol]; exists {
+	return key
+}
+
+//distinguishable finds the first partition other than current into which
+//exactly one of two states moves, together with the first symbol doing so.
+//first and second hold the partition each of the sorted symbols leads to, -1
+//when there is no transition on it
+func distinguishable(first, second []int, current int, symbols []string) (int, string, bool) {
+	partition, witness := -1, ""
+	for i, symbol := range symbols {
+		if first[i] == second[i] {
 			continue
 		}
-		if intArray.IndexOf(targetState, otherPartition) != -1 && intArray.IndexOf(dfa.Transitions[first][symbol], otherPartition) == -1 {
-			return true
-		}
-		if intArray.IndexOf(targetState, otherPartition) == -1 && intArray.IndexOf(dfa.Transitions[first][symbol], otherPartition) != -1 {
-			return true
+		for _, candidate := range []int{first[i], second[i]} {
+			if candidate != -1 && candidate != current && (partition == -1 || candidate < partition) {
+				partition, witness = candidate, symbol
+			}
 		}
 	}
-	return false
+	return partition, witness, partition != -1
 }
 
 //Minimize a DFA, transform a DFA to the DFA with minimal states
+//
+//Unreachable states and states that can never accept are dropped, only a DFA
+//for the empty language keeps its start state, without transitions
 func (dfa *DFA) Minimize() {
+	dfa.minimize(context.Background(), nil)
+}
+
+//minimize a DFA in place, stops with ctx.Err() once ctx is done
+//
+//Every refinement round is recorded into trace unless it is nil
+func (dfa *DFA) minimize(ctx context.Context, trace *MinimizationTrace) error {
 	sinkState := -1
 	statePartitions := make([][]int, 0)
-	statePartitions = append(statePartitions, make([]int, 0))
-	statePartitions = append(statePartitions, make([]int, 0))
+	partitionKeys := make([]string, 0)
 	queue := []int{dfa.StartStates[0]}
-	visited := []int{dfa.StartStates[0]}
+	visited := map[int]bool{dfa.StartStates[0]: true}
 	currentState := queue[0]
 	for currentState != -1 {
 		for _, symbol := range dfa.Alphabet {
@@ -221,15 +214,20 @@ func (dfa *DFA) Minimize() {
 				dfa.Transitions[currentState][symbol] = sinkState
 			}
 		}
-		if intArray.IndexOf(currentState, dfa.AcceptStates) == -1 {
-			statePartitions[0] = append(statePartitions[0], currentState)
-		} else {
-			statePartitions[1] = append(statePartitions[1], currentState)
+		//states start out partitioned by whether they accept and by their accept labels
+		partitionIndex := stringArray.IndexOf(dfa.acceptKey(currentState), partitionKeys)
+		if partitionIndex == -1 {
+			partitionKeys = append(partitionKeys, dfa.acceptKey(currentState))
+			statePartitions = append(statePartitions, make([]int, 0))
+			partitionIndex = len(statePartitions) - 1
 		}
-		for _, nextState := range dfa.Transitions[currentState] {
-			if intArray.IndexOf(nextState, visited) == -1 {
+		statePartitions[partitionIndex] = append(statePartitions[partitionIndex], currentState)
+		//following symbols in sorted order keeps the partition numbering stable
+		for _, symbol := range dfa.sortedSymbols(currentState) {
+			nextState := dfa.Transitions[currentState][symbol]
+			if !visited[nextState] {
 				queue = append(queue, nextState)
-				visited = append(visited, nextState)
+				visited[nextState] = true
 			}
 		}
 		queue = queue[1:]
@@ -239,65 +237,110 @@ func (dfa *DFA) Minimize() {
 			currentState = -1
 		}
 	}
-	if len(statePartitions[0]) == 0 {
-		statePartitions = statePartitions[1:]
-	} else if len(statePartitions[1]) == 0 {
-		statePartitions = statePartitions[:1]
+	//targets of every reachable state on each symbol never change, only the partitions they are in
+	allSymbols := dfa.symbols()
+	targets := make(map[int][]int, len(visited))
+	rows := make(map[int][]int, len(visited))
+	maxState := 0
+	for state := range visited {
+		if state > maxState {
+			maxState = state
+		}
+		targets[state] = make([]int, len(allSymbols))
+		rows[state] = make([]int, len(allSymbols))
+		for i, symbol := range allSymbols {
+			targets[state][i] = -1
+			if targetState, exists := dfa.Transitions[state][symbol]; exists {
+				targets[state][i] = targetState
+			}
+		}
 	}
 	numPartitions := 0
 	for len(statePartitions) != numPartitions {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		numPartitions = len(statePartitions)
 		previousPartitions := make([][]int, numPartitions)
 		for i := 0; i < numPartitions; i++ {
 			previousPartitions[i] = make([]int, len(statePartitions[i]))
 			copy(previousPartitions[i], statePartitions[i])
 		}
-		splitFlag := false
+		if trace != nil {
+			trace.Rounds = append(trace.Rounds, MinimizationRound{Partitions: previousPartitions})
+		}
+		partitionOf := make([]int, maxState+1)
+		for i, partition := range previousPartitions {
+			for _, state := range partition {
+				partitionOf[state] = i
+			}
+		}
+		//rows hold the partition every state moves into on each symbol
+		for state, row := range rows {
+			for i, targetState := range targets[state] {
+				row[i] = -1
+				if targetState != -1 {
+					row[i] = partitionOf[targetState]
+				}
+			}
+		}
 		for currentPartitionIndex := 0; currentPartitionIndex < numPartitions; currentPartitionIndex++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			//states split off a partition must not end up together with states split off another
+			splitIndex := -1
 			for i := 0; i < len(statePartitions[currentPartitionIndex])-1; i++ {
 				for j := i + 1; j < len(statePartitions[currentPartitionIndex]); j++ {
 					firstState := statePartitions[currentPartitionIndex][i]
 					secondState := statePartitions[currentPartitionIndex][j]
-					for k := 0; k < numPartitions; k++ {
-						if k == currentPartitionIndex {
-							continue
+					if k, symbol, split := distinguishable(rows[firstState], rows[secondState], currentPartitionIndex, allSymbols); split {
+						intArray.Remove(secondState, &statePartitions[currentPartitionIndex])
+						if splitIndex == -1 {
+							statePartitions = append(statePartitions, make([]int, 0))
+							splitIndex = len(statePartitions) - 1
 						}
-						if dfa.distinguishable(firstState, secondState, previousPartitions[k]) {
-							intArray.Remove(secondState, &statePartitions[currentPartitionIndex])
-							if !splitFlag {
-								statePartitions = append(statePartitions, make([]int, 0))
-								splitFlag = true
-							}
-							statePartitions[numPartitions] = append(statePartitions[numPartitions], secondState)
-							j--
-							break
+						statePartitions[splitIndex] = append(statePartitions[splitIndex], secondState)
+						if trace != nil {
+							round := &trace.Rounds[len(trace.Rounds)-1]
+							round.Splits = append(round.Splits, MinimizationSplit{
+								State:     secondState,
+								Witness:   firstState,
+								Symbol:    symbol,
+								Partition: k,
+								From:      currentPartitionIndex,
+								To:        splitIndex,
+							})
 						}
+						j--
 					}
 				}
 			}
 		}
 	}
-	if sinkState != -1 {
-		for i := 0; i < len(statePartitions); i++ {
-			if intArray.IndexOf(sinkState, statePartitions[i]) != -1 {
-				if len(statePartitions[i]) == 1 {
-					if i == len(statePartitions)-1 {
-						statePartitions = statePartitions[:i]
-					} else {
-						statePartitions = append(statePartitions[:i], statePartitions[i+1:]...)
-					}
-				} else {
-					intArray.Remove(sinkState, &statePartitions[i])
-				}
-				break
-			}
+	//states that can never accept, the sink state included, all end up in one
+	//partition which is dropped unless the start state is in it
+	live := dfa.liveStates()
+	for i := 0; i < len(statePartitions); i++ {
+		if live[statePartitions[i][0]] {
+			continue
 		}
+		if intArray.IndexOf(dfa.StartStates[0], statePartitions[i]) != -1 {
+			intArray.Remove(sinkState, &statePartitions[i])
+			break
+		}
+		statePartitions = append(statePartitions[:i], statePartitions[i+1:]...)
+		break
 	}
 	minDFA := New()
 	minDFA.Alphabet = dfa.Alphabet
 	minStates := make(map[int]int, 0)
+	finalPartitionOf := make(map[int]int)
 	for i := 0; i < len(statePartitions); i++ {
 		minStates[i] = minDFA.AddState(false, false)
+		for _, state := range statePartitions[i] {
+			finalPartitionOf[state] = i
+		}
 	}
 	for i := 0; i < len(statePartitions); i++ {
 		for _, state := range statePartitions[i] {
@@ -306,68 +349,181 @@ func (dfa *DFA) Minimize() {
 			}
 			if intArray.IndexOf(state, dfa.AcceptStates) != -1 && intArray.IndexOf(minStates[i], minDFA.AcceptStates) == -1 {
 				minDFA.AcceptStates = append(minDFA.AcceptStates, minStates[i])
+				if labels, exists := dfa.AcceptLabels[state]; exists {
+					minDFA.AcceptLabels[minStates[i]] = labels
+				}
 			}
-			for symbol, targetState := range dfa.Transitions[state] {
-				if targetState == sinkState {
+			for _, symbol := range dfa.sortedSymbols(state) {
+				targetState := dfa.Transitions[state][symbol]
+				if !live[targetState] {
 					continue
 				}
-				for j := 0; j < len(statePartitions); j++ {
-					if intArray.IndexOf(targetState, statePartitions[j]) != -1 {
-						minDFA.Transitions[minStates[i]][symbol] = minStates[j]
-						break
-					}
+				if j, exists := finalPartitionOf[targetState]; exists {
+					minDFA.Transitions[minStates[i]][symbol] = minStates[j]
 				}
 			}
 		}
 	}
+	if trace != nil {
+		trace.SinkState = sinkState
+		trace.Partitions = statePartitions
+		trace.StateMapping = make(map[int]int)
+		for i := 0; i < len(statePartitions); i++ {
+			for _, state := range statePartitions[i] {
+				trace.StateMapping[state] = minStates[i]
+			}
+		}
+	}
 	*dfa = *minDFA
+	return nil
+}
+
+//MinimizeBrzozowski minimizes a DFA by reversing and determinising it twice
+//
+//Determinising the reverse of a DFA whose states are all reachable yields
+//a minimal DFA, so no partition refinement is needed. Accept labels do not
+//survive the reversals and are dropped
+func (dfa *DFA) MinimizeBrzozowski() {
+	reverseDFA := FromNFA(dfa.reverseNFA())
+	*dfa = *FromNFA(reverseDFA.reverseNFA()).trim()
+}
+
+//liveStates of a DFA, the states from which an accept state can be reached
+func (dfa *DFA) liveStates() map[int]bool {
+	live := make(map[int]bool)
+	for _, acceptState := range dfa.AcceptStates {
+		live[acceptState] = true
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, state := range dfa.States {
+			if live[state] {
+				continue
+			}
+			for _, transitionState := range dfa.Transitions[state] {
+				if live[transitionState] {
+					live[state] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	return live
+}
+
+//trim returns a copy of a DFA without the states that can never reach an accept state
+//
+//The start state is always kept so a DFA for the empty language still has one
+func (dfa *DFA) trim() *DFA {
+	live := dfa.liveStates()
+	keep := make(map[int]bool, len(live)+1)
+	for state := range live {
+		keep[state] = true
+	}
+	for _, startState := range dfa.StartStates {
+		keep[startState] = true
+	}
+	trimDFA := New()
+	trimDFA.Alphabet = dfa.Alphabet
+	stateMappings := make(map[int]int)
+	for _, state := range dfa.States {
+		if keep[state] {
+			stateMappings[state] = trimDFA.AddState(intArray.IndexOf(state, dfa.StartStates) != -1, intArray.IndexOf(state, dfa.AcceptStates) != -1)
+			if labels, exists := dfa.AcceptLabels[state]; exists {
+				trimDFA.AcceptLabels[stateMappings[state]] = append([]int(nil), labels...)
+			}
+		}
+	}
+	for _, state := range dfa.States {
+		for symbol, transitionState := range dfa.Transitions[state] {
+			if keep[state] && live[transitionState] {
+				trimDFA.AddTransition(stateMappings[state], symbol, stateMappings[transitionState])
+			}
+		}
+	}
+	return trimDFA
 }
 
 //FromNFA create a DFA from a NFA
 func FromNFA(NFA *nfa.NFA) *DFA {
+	dfa, _ := fromNFA(context.Background(), NFA, Limits{}, nil)
+	return dfa
+}
+
+//fromNFA runs the subset construction, stops once ctx is done or limits are exceeded
+//
+//Every step is recorded into trace unless it is nil
+func fromNFA(ctx context.Context, NFA *nfa.NFA, limits Limits, trace *SubsetTrace) (*DFA, error) {
 	epsilonClosures := NFA.GetEpsilonClosures()
 	collapsedStates := make(map[int][]int, 0)
 	collapsedTransitions := make(map[int]map[string]int, 0)
-	collapsedStates[0] = epsilonClosures[NFA.StartStates[0]]
+	//the start subset closes over every start state of the NFA
+	collapsedStates[0] = make([]int, 0)
+	for _, startState := range NFA.StartStates {
+		for _, closureState := range epsilonClosures[startState] {
+			if intArray.IndexOf(closureState, collapsedStates[0]) == -1 {
+				collapsedStates[0] = append(collapsedStates[0], closureState)
+			}
+		}
+	}
+	sort.Ints(collapsedStates[0])
+	subsetIndexes := map[string]int{subsetKey(collapsedStates[0]): 0}
 	queue := []int{0}
-	visited := []int{0}
 	currentState := collapsedStates[0]
 	currentCollapsedIndex := queue[0]
+	//subsets are discovered following symbols in sorted order
+	symbols := append([]string(nil), NFA.Alphabet...)
+	sort.Strings(symbols)
 	for currentState != nil {
-		for _, symbol := range NFA.Alphabet {
-			transitionStates := make([]int, 0)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for _, symbol := range symbols {
+			moveStates := make([]int, 0)
 			for _, state := range currentState {
-				if NFA.Transitions[state][symbol] != nil {
-					for _, transitionState := range NFA.Transitions[state][symbol] {
-						for _, closureState := range epsilonClosures[transitionState] {
-							if intArray.IndexOf(closureState, transitionStates) == -1 {
-								transitionStates = append(transitionStates, closureState)
-							}
-						}
-						if intArray.IndexOf(transitionState, transitionStates) == -1 {
-							transitionStates = append(transitionStates, transitionState)
-						}
+				for _, transitionState := range NFA.Transitions[state][symbol] {
+					if intArray.IndexOf(transitionState, moveStates) == -1 {
+						moveStates = append(moveStates, transitionState)
 					}
 				}
 			}
-			if len(transitionStates) != 0 {
-				collapsed := len(collapsedStates)
-				for index, currentCollapsed := range collapsedStates {
-					if intArray.Equals(currentCollapsed, transitionStates) {
-						collapsed = index
+			transitionStates := make([]int, 0)
+			for _, moveState := range moveStates {
+				for _, closureState := range epsilonClosures[moveState] {
+					if intArray.IndexOf(closureState, transitionStates) == -1 {
+						transitionStates = append(transitionStates, closureState)
 					}
 				}
+			}
+			sort.Ints(moveStates)
+			sort.Ints(transitionStates)
+			step := SubsetStep{From: currentCollapsedIndex, Symbol: symbol, Move: moveStates, Closure: transitionStates, To: -1}
+			if len(transitionStates) != 0 {
+				key := subsetKey(transitionStates)
+				collapsed, exists := subsetIndexes[key]
+				if !exists {
+					collapsed = len(collapsedStates)
+				}
 				//collapsed is either a new entry for the map collapsedStates or an existing entry in collapsedStates
+				if limits.MaxStates > 0 && collapsed >= limits.MaxStates {
+					return nil, stateLimitError(limits.MaxStates)
+				}
+				step.To = collapsed
+				step.New = collapsed == len(collapsedStates)
 				collapsedStates[collapsed] = transitionStates
+				subsetIndexes[key] = collapsed
 				if collapsedTransitions[currentCollapsedIndex] == nil {
 					collapsedTransitions[currentCollapsedIndex] = make(map[string]int, 0)
 				}
 				collapsedTransitions[currentCollapsedIndex][symbol] = collapsed
-				if intArray.IndexOf(collapsed, visited) == -1 {
+				if step.New {
 					queue = append(queue, collapsed)
-					visited = append(visited, collapsed)
 				}
 			}
+			if trace != nil {
+				trace.Steps = append(trace.Steps, step)
+			}
 		}
 		queue = queue[1:]
 		if len(queue) != 0 {
@@ -380,22 +536,32 @@ func FromNFA(NFA *nfa.NFA) *DFA {
 	dfa := New()
 	dfa.Alphabet = NFA.Alphabet
 	for i := 0; i < len(collapsedStates); i++ {
-		newState := dfa.AddState(false, false)
+		newState := dfa.AddState(i == 0, false)
 		for _, state := range collapsedStates[i] {
-			if intArray.IndexOf(state, NFA.StartStates) != -1 {
-				if intArray.IndexOf(newState, dfa.StartStates) == -1 {
-					dfa.StartStates = append(dfa.StartStates, newState)
-				}
-			}
 			if intArray.IndexOf(state, NFA.AcceptStates) != -1 {
 				if intArray.IndexOf(newState, dfa.AcceptStates) == -1 {
 					dfa.AcceptStates = append(dfa.AcceptStates, newState)
 				}
+				for _, label := range NFA.AcceptLabels[state] {
+					if intArray.IndexOf(label, dfa.AcceptLabels[newState]) == -1 {
+						dfa.AcceptLabels[newState] = append(dfa.AcceptLabels[newState], label)
+					}
+				}
 			}
 		}
-		for symbol, transition := range collapsedTransitions[i] {
-			dfa.AddTransition(newState, symbol, transition)
+		sort.Ints(dfa.AcceptLabels[newState])
+		for _, symbol := range symbols {
+			if transition, exists := collapsedTransitions[i][symbol]; exists {
+				dfa.AddTransition(newState, symbol, transition)
+			}
+		}
+		if trace != nil {
+			trace.Subsets = append(trace.Subsets, collapsedStates[i])
 		}
 	}
-	return dfa
+	if trace != nil {
+		trace.Alphabet = symbols
+		trace.AcceptStates = dfa.AcceptStates
+	}
+	return dfa, nil
 }
@@ -0,0 +1,93 @@
+package dfa
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ChristopherCamara/finiteAutomata/internal/dot"
+	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+	"github.com/ChristopherCamara/finiteAutomata/internal/stringArray"
+	"github.com/ChristopherCamara/finiteAutomata/nfa"
+)
+
+//DOTOptions change how WriteDOT draws an automaton, they are shared with nfa.DOTOptions
+type DOTOptions = nfa.DOTOptions
+
+//Format of an image made by Render, shared with nfa.Format
+type Format = nfa.Format
+
+//Layout is the Graphviz engine used by Render, shared with nfa.Layout
+type Layout = nfa.Layout
+
+//RenderOptions change how Render draws an automaton, shared with nfa.RenderOptions
+type RenderOptions = nfa.RenderOptions
+
+//image formats and layouts supported by Render
+const (
+	SVG         = nfa.SVG
+	PNG         = nfa.PNG
+	JPG         = nfa.JPG
+	DotLayout   = nfa.DotLayout
+	CircoLayout = nfa.CircoLayout
+	NeatoLayout = nfa.NeatoLayout
+)
+
+//WriteDOT writes a DFA as Graphviz DOT text
+func (dfa *DFA) WriteDOT(w io.Writer, opts DOTOptions) error {
+	graph := dot.Graph{RankDir: opts.RankDir, SeparateEdges: opts.SeparateEdges}
+	for _, state := range dfa.States {
+		graph.Nodes = append(graph.Nodes, dot.Node{
+			ID:        state,
+			Label:     opts.StateLabels[state],
+			Start:     intArray.IndexOf(state, dfa.StartStates) != -1,
+			Accept:    intArray.IndexOf(state, dfa.AcceptStates) != -1,
+			Highlight: intArray.IndexOf(state, opts.Highlight) != -1,
+		})
+	}
+	for _, state := range dfa.States {
+		for _, symbol := range dfa.sortedSymbols(state) {
+			graph.Edges = append(graph.Edges, dot.Edge{From: state, To: dfa.Transitions[state][symbol], Label: symbol})
+		}
+	}
+	return graph.Write(w)
+}
+
+//ReadDOT builds a DFA from a DOT digraph in the shape WriteDOT and SaveGraphviz produce
+//
+//States are numbered in the order their nodes first appear and symbols are added
+//to the Alphabet in the order they are first seen. Epsilon edges, more than one
+//start state or two edges leaving a state on the same symbol are errors
+func ReadDOT(r io.Reader) (*DFA, error) {
+	automaton, err := dot.ReadAutomaton(r)
+	if err != nil {
+		return nil, err
+	}
+	newDFA := New()
+	stateMappings := make(map[string]int)
+	for _, name := range automaton.States {
+		stateMappings[name] = newDFA.AddState(false, false)
+	}
+	for _, name := range automaton.StartStates {
+		newDFA.StartStates = append(newDFA.StartStates, stateMappings[name])
+	}
+	for _, name := range automaton.AcceptStates {
+		newDFA.AcceptStates = append(newDFA.AcceptStates, stateMappings[name])
+	}
+	for _, transition := range automaton.Transitions {
+		if transition.Epsilon {
+			return nil, fmt.Errorf("epsilon edge %s -> %s in a DFA", transition.From, transition.To)
+		}
+		sourceState := stateMappings[transition.From]
+		if _, exists := newDFA.Transitions[sourceState][transition.Symbol]; exists {
+			return nil, fmt.Errorf("state %s has more than one edge on symbol %q", transition.From, transition.Symbol)
+		}
+		if stringArray.IndexOf(transition.Symbol, newDFA.Alphabet) == -1 {
+			newDFA.Alphabet = append(newDFA.Alphabet, transition.Symbol)
+		}
+		newDFA.AddTransition(sourceState, transition.Symbol, stateMappings[transition.To])
+	}
+	if err := newDFA.Validate(); err != nil {
+		return nil, err
+	}
+	return newDFA, nil
+}
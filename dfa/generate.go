@@ -0,0 +1,127 @@
+package dfa
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+)
+
+//GenerateGo writes a standalone Go source file to w with a function funcName(input string) bool
+//
+//The function is a switch based state machine reading input one rune per symbol,
+//so every symbol of the DFA must be a single valid UTF-8 encoded rune. Invalid
+//UTF-8 in the input never matches a symbol, not even "\uFFFD". The DFA is
+//emitted as is, Minimize it first to get the smallest function
+func (dfa *DFA) GenerateGo(w io.Writer, pkg, funcName string) error {
+	states := append([]int(nil), dfa.States...)
+	sort.Ints(states)
+	replacementSymbol := false
+	for _, state := range states {
+		for symbol := range dfa.Transitions[state] {
+			if !utf8.ValidString(symbol) || utf8.RuneCountInString(symbol) != 1 {
+				return fmt.Errorf("symbol %q of state %d is not a single rune", symbol, state)
+			}
+			replacementSymbol = replacementSymbol || symbol == string(utf8.RuneError)
+		}
+	}
+	var source bytes.Buffer
+	fmt.Fprintf(&source, "// Code generated by dfa.GenerateGo. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&source, "package %s\n\n", pkg)
+	if replacementSymbol && len(dfa.StartStates) != 0 {
+		fmt.Fprintf(&source, "import \"unicode/utf8\"\n\n")
+	}
+	fmt.Fprintf(&source, "// %s reports whether input is in the language of the generated DFA.\n", funcName)
+	fmt.Fprintf(&source, "func %s(input string) bool {\n", funcName)
+	if len(dfa.StartStates) == 0 {
+		fmt.Fprintf(&source, "return false\n}\n")
+		return writeFormatted(w, source.Bytes())
+	}
+	transitions := 0
+	for _, state := range states {
+		transitions += len(dfa.Transitions[state])
+	}
+	if transitions == 0 {
+		//there is nothing to loop over, only the empty input can be accepted
+		if intArray.IndexOf(dfa.StartStates[0], dfa.AcceptStates) != -1 {
+			fmt.Fprintf(&source, "return input == \"\"\n}\n")
+		} else {
+			fmt.Fprintf(&source, "return false\n}\n")
+		}
+		return writeFormatted(w, source.Bytes())
+	}
+	fmt.Fprintf(&source, "state := %d\n", dfa.StartStates[0])
+	if replacementSymbol {
+		//range decodes invalid UTF-8 to utf8.RuneError as well, which must not match the symbol
+		fmt.Fprintf(&source, "for index, symbol := range input {\n")
+		fmt.Fprintf(&source, "if _, size := utf8.DecodeRuneInString(input[index:]); symbol == utf8.RuneError && size == 1 {\nreturn false\n}\n")
+	} else {
+		fmt.Fprintf(&source, "for _, symbol := range input {\n")
+	}
+	fmt.Fprintf(&source, "switch state {\n")
+	for _, state := range states {
+		if len(dfa.Transitions[state]) == 0 {
+			continue
+		}
+		fmt.Fprintf(&source, "case %d:\n", state)
+		fmt.Fprintf(&source, "switch symbol {\n")
+		//group symbols sharing a target state into one case
+		symbolsByTarget := make(map[int][]rune)
+		targets := make([]int, 0)
+		for symbol, targetState := range dfa.Transitions[state] {
+			if _, exists := symbolsByTarget[targetState]; !exists {
+				targets = append(targets, targetState)
+			}
+			current, _ := utf8.DecodeRuneInString(symbol)
+			symbolsByTarget[targetState] = append(symbolsByTarget[targetState], current)
+		}
+		sort.Ints(targets)
+		for _, targetState := range targets {
+			symbols := symbolsByTarget[targetState]
+			sort.Slice(symbols, func(i, j int) bool { return symbols[i] < symbols[j] })
+			fmt.Fprintf(&source, "case ")
+			for index, symbol := range symbols {
+				if index != 0 {
+					fmt.Fprintf(&source, ", ")
+				}
+				fmt.Fprintf(&source, "%s", strconv.QuoteRune(symbol))
+			}
+			fmt.Fprintf(&source, ":\nstate = %d\n", targetState)
+		}
+		fmt.Fprintf(&source, "default:\nreturn false\n}\n")
+	}
+	fmt.Fprintf(&source, "default:\nreturn false\n}\n}\n")
+	acceptStates := make([]int, 0)
+	for _, state := range states {
+		if intArray.IndexOf(state, dfa.AcceptStates) != -1 {
+			acceptStates = append(acceptStates, state)
+		}
+	}
+	if len(acceptStates) == 0 {
+		fmt.Fprintf(&source, "return false\n}\n")
+		return writeFormatted(w, source.Bytes())
+	}
+	fmt.Fprintf(&source, "switch state {\ncase ")
+	for index, state := range acceptStates {
+		if index != 0 {
+			fmt.Fprintf(&source, ", ")
+		}
+		fmt.Fprintf(&source, "%d", state)
+	}
+	fmt.Fprintf(&source, ":\nreturn true\n}\nreturn false\n}\n")
+	return writeFormatted(w, source.Bytes())
+}
+
+func writeFormatted(w io.Writer, source []byte) error {
+	formatted, err := format.Source(source)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(formatted)
+	return err
+}
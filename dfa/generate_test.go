@@ -0,0 +1,127 @@
+package dfa
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	regexparser "github.com/ChristopherCamara/finiteAutomata/regexParser"
+)
+
+//generatedCase is a DFA compiled into function name, run on every input
+type generatedCase struct {
+	name   string
+	dfa    *DFA
+	inputs map[string]bool
+}
+
+//runGenerated builds the generated functions into a program and returns what they answered for every input
+func runGenerated(t *testing.T, cases []generatedCase) []string {
+	t.Helper()
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+	directory, err := ioutil.TempDir("", "generate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+	if err := ioutil.WriteFile(filepath.Join(directory, "go.mod"), []byte("module generated\n\ngo 1.15\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var program bytes.Buffer
+	fmt.Fprintf(&program, "package main\n\nimport \"fmt\"\n\nfunc main() {\n")
+	for _, current := range cases {
+		var source bytes.Buffer
+		if err := current.dfa.GenerateGo(&source, "main", current.name); err != nil {
+			t.Fatalf("%s: %v", current.name, err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(directory, current.name+".go"), source.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+		for _, input := range sortedInputs(current.inputs) {
+			fmt.Fprintf(&program, "\tfmt.Println(%q, %s(%q))\n", current.name+" "+input, current.name, input)
+		}
+	}
+	fmt.Fprintf(&program, "}\n")
+	if err := ioutil.WriteFile(filepath.Join(directory, "main.go"), program.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	command := exec.Command(goTool, "run", ".")
+	command.Dir = directory
+	command.Env = append(os.Environ(), "GOFLAGS=", "GO111MODULE=on")
+	output, err := command.CombinedOutput()
+	if err != nil {
+		t.Fatalf("running the generated code failed: %v\n%s", err, output)
+	}
+	return strings.Split(strings.TrimSpace(string(output)), "\n")
+}
+
+func sortedInputs(inputs map[string]bool) []string {
+	sorted := make([]string, 0, len(inputs))
+	for input := range inputs {
+		sorted = append(sorted, input)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func TestGenerateGoRuns(t *testing.T) {
+	parser := new(regexparser.RegexParser)
+	abb := FromNFA(parser.ParseToNFA("(a|b)*abb"))
+	abb.Minimize()
+	//"\uFFFD" is a valid symbol, invalid UTF-8 in the input must still not match it
+	replacement := New()
+	startState := replacement.AddState(true, false)
+	acceptState := replacement.AddState(false, true)
+	replacement.Alphabet = []string{"\uFFFD", "é"}
+	replacement.AddTransition(startState, "\uFFFD", acceptState)
+	replacement.AddTransition(acceptState, "é", acceptState)
+	nothing := New()
+	nothing.AddState(true, false)
+	empty := New()
+	empty.AddState(true, true)
+	cases := []generatedCase{
+		{"matchABB", abb, map[string]bool{"": false, "abb": true, "babb": true, "abba": false, "ab": false, "abbc": false, "\xffabb": false}},
+		{"matchReplacement", replacement, map[string]bool{"\uFFFD": true, "\uFFFDéé": true, "\xff": false, "\xffé": false, "é": false, "\uFFFD\xff": false}},
+		{"matchNothing", nothing, map[string]bool{"": false, "a": false}},
+		{"matchEmpty", empty, map[string]bool{"": true, "a": false}},
+	}
+	lines := runGenerated(t, cases)
+	expected := make([]string, 0)
+	for _, current := range cases {
+		for _, input := range sortedInputs(current.inputs) {
+			expected = append(expected, fmt.Sprintf("%s %t", current.name+" "+input, current.inputs[input]))
+		}
+	}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("generated code answered\n%s\nexpected\n%s", strings.Join(lines, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestGenerateGoRejectsByteSymbols(t *testing.T) {
+	runes := New()
+	state := runes.AddState(true, true)
+	runes.Alphabet = []string{"é"}
+	runes.AddTransition(state, "é", state)
+	bytesDFA, err := runes.CompileUTF8()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bytesDFA.GenerateGo(new(bytes.Buffer), "main", "match"); err == nil {
+		t.Error("expected an error for symbols that are not valid UTF-8")
+	}
+	multiple := New()
+	state = multiple.AddState(true, true)
+	multiple.AddTransition(state, "ab", state)
+	if err := multiple.GenerateGo(new(bytes.Buffer), "main", "match"); err == nil {
+		t.Error("expected an error for a symbol of two runes")
+	}
+}
@@ -0,0 +1,133 @@
+package dfa
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	regexparser "github.com/ChristopherCamara/finiteAutomata/regexParser"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+//goldenCases name the regexes whose output is kept in testdata
+var goldenCases = []struct {
+	name  string
+	regex string
+}{
+	{"union", "b|ab"},
+	{"suffix", "(a|b)*abb"},
+	{"closure", "(ab|a)*"},
+}
+
+//checkGolden compares output with testdata/name, or rewrites it when -update is set
+func checkGolden(t *testing.T, name string, output []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := ioutil.WriteFile(path, output, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	expected, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(output, expected) {
+		t.Errorf("%s does not match\ngot:\n%s\nexpected:\n%s", path, output, expected)
+	}
+}
+
+//capturePrint returns what Print writes to stdout
+func capturePrint(t *testing.T, dfa *DFA) []byte {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+	dfa.Print()
+	os.Stdout = stdout
+	writer.Close()
+	output, err := ioutil.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return output
+}
+
+func goldenDFA(regex string) *DFA {
+	parser := new(regexparser.RegexParser)
+	return FromNFA(parser.ParseToNFA(regex))
+}
+
+func TestFromNFAGolden(t *testing.T) {
+	for _, current := range goldenCases {
+		checkGolden(t, current.name+".fromNFA.golden", capturePrint(t, goldenDFA(current.regex)))
+	}
+}
+
+func TestMinimizeGolden(t *testing.T) {
+	for _, current := range goldenCases {
+		dfa := goldenDFA(current.regex)
+		dfa.Minimize()
+		checkGolden(t, current.name+".minimize.golden", capturePrint(t, dfa))
+	}
+}
+
+func TestWriteDOTGolden(t *testing.T) {
+	for _, current := range goldenCases {
+		var output bytes.Buffer
+		if err := goldenDFA(current.regex).WriteDOT(&output, DOTOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		checkGolden(t, current.name+".dot.golden", output.Bytes())
+	}
+}
+
+func TestJSONGolden(t *testing.T) {
+	for _, current := range goldenCases {
+		dfa := goldenDFA(current.regex)
+		output, err := json.MarshalIndent(dfa, "", "\t")
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkGolden(t, current.name+".json.golden", output)
+		decoded := New()
+		if err := json.Unmarshal(output, decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(capturePrint(t, decoded), capturePrint(t, dfa)) {
+			t.Errorf("%s: decoding the JSON does not give back the DFA", current.name)
+		}
+	}
+}
+
+func TestSubsetTraceGolden(t *testing.T) {
+	for _, current := range goldenCases {
+		parser := new(regexparser.RegexParser)
+		_, trace := FromNFAWithTrace(parser.ParseToNFA(current.regex))
+		var output bytes.Buffer
+		if err := trace.WriteTable(&output); err != nil {
+			t.Fatal(err)
+		}
+		checkGolden(t, current.name+".subsets.golden", output.Bytes())
+	}
+}
+
+//the columns of the table must follow the order of the steps, b|ab has the unsorted alphabet [b a]
+func TestSubsetTraceColumns(t *testing.T) {
+	parser := new(regexparser.RegexParser)
+	_, trace := FromNFAWithTrace(parser.ParseToNFA("b|ab"))
+	for index, step := range trace.Steps {
+		if symbol := trace.Alphabet[index%len(trace.Alphabet)]; step.Symbol != symbol {
+			t.Fatalf("step %d is on %s but falls in the column of %s", index, step.Symbol, symbol)
+		}
+	}
+}
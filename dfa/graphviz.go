@@ -0,0 +1,32 @@
+//go:build cgo
+// +build cgo
+
+package dfa
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/ChristopherCamara/finiteAutomata/internal/render"
+)
+
+//Render a DFA as an image using Graphviz
+func (dfa *DFA) Render(w io.Writer, format Format, opts RenderOptions) error {
+	if err := opts.Check(format); err != nil {
+		return err
+	}
+	var dotText bytes.Buffer
+	if err := dfa.WriteDOT(&dotText, opts.DOTOptions); err != nil {
+		return err
+	}
+	return render.DOT(dotText.Bytes(), w, string(format), string(opts.Layout))
+}
+
+//SaveGraphviz renders a DFA as PNG into fileName with .png appended, no file is written when rendering fails
+//
+//Deprecated: use Render, which lets the caller pick the format and destination
+func (dfa *DFA) SaveGraphviz(fileName string) error {
+	return render.File(fileName+".png", func(w io.Writer) error {
+		return dfa.Render(w, PNG, RenderOptions{})
+	})
+}
@@ -0,0 +1,41 @@
+package dfa
+
+import (
+	"sort"
+
+	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+)
+
+//InverseHomomorphism returns a DFA for the words whose image under h is in the language of d
+//
+//The symbols of the result are the keys of h. A state moves on a symbol to
+//the state d reaches from it by reading the word h maps the symbol to, so the
+//result keeps the states of d and stays deterministic
+func InverseHomomorphism(d *DFA, h map[string][]string) *DFA {
+	newDFA := New()
+	for symbol := range h {
+		newDFA.Alphabet = append(newDFA.Alphabet, symbol)
+	}
+	sort.Strings(newDFA.Alphabet)
+	stateMappings := make(map[int]int)
+	for _, state := range d.States {
+		stateMappings[state] = newDFA.AddState(intArray.IndexOf(state, d.StartStates) != -1, intArray.IndexOf(state, d.AcceptStates) != -1)
+		if labels, exists := d.AcceptLabels[state]; exists {
+			newDFA.AcceptLabels[stateMappings[state]] = append([]int(nil), labels...)
+		}
+	}
+	for _, state := range d.States {
+		for _, symbol := range newDFA.Alphabet {
+			currentState, exists := state, true
+			for _, imageSymbol := range h[symbol] {
+				if currentState, exists = d.Transitions[currentState][imageSymbol]; !exists {
+					break
+				}
+			}
+			if exists {
+				newDFA.AddTransition(stateMappings[state], symbol, stateMappings[currentState])
+			}
+		}
+	}
+	return newDFA
+}
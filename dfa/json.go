@@ -0,0 +1,104 @@
+package dfa
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+//JSONVersion is the schema version written by MarshalJSON and accepted by UnmarshalJSON
+//
+//Version 1 of the schema looks like
+//
+//	{
+//		"version": 1,
+//		"alphabet": ["a", "b"],
+//		"states": [0, 1],
+//		"start": [0],
+//		"accept": [1],
+//		"transitions": [{"from": 0, "symbol": "a", "to": 1}],
+//		"labels": [{"state": 1, "labels": [0]}]
+//	}
+//
+//"labels" holds AcceptLabels and may be left out
+const JSONVersion = 1
+
+type jsonDFA struct {
+	Version     int                `json:"version"`
+	Alphabet    []string           `json:"alphabet"`
+	States      []int              `json:"states"`
+	Start       []int              `json:"start"`
+	Accept      []int              `json:"accept"`
+	Transitions []jsonTransition   `json:"transitions"`
+	Labels      []jsonAcceptLabels `json:"labels,omitempty"`
+}
+
+type jsonTransition struct {
+	From   int    `json:"from"`
+	Symbol string `json:"symbol"`
+	To     int    `json:"to"`
+}
+
+type jsonAcceptLabels struct {
+	State  int   `json:"state"`
+	Labels []int `json:"labels"`
+}
+
+//MarshalJSON encodes a DFA using the schema described by JSONVersion
+func (dfa *DFA) MarshalJSON() ([]byte, error) {
+	encoded := jsonDFA{
+		Version:     JSONVersion,
+		Alphabet:    dfa.Alphabet,
+		States:      dfa.States,
+		Start:       dfa.StartStates,
+		Accept:      dfa.AcceptStates,
+		Transitions: make([]jsonTransition, 0),
+	}
+	for _, state := range dfa.States {
+		for _, symbol := range dfa.sortedSymbols(state) {
+			encoded.Transitions = append(encoded.Transitions, jsonTransition{From: state, Symbol: symbol, To: dfa.Transitions[state][symbol]})
+		}
+		if labels, exists := dfa.AcceptLabels[state]; exists {
+			encoded.Labels = append(encoded.Labels, jsonAcceptLabels{State: state, Labels: labels})
+		}
+	}
+	return json.Marshal(encoded)
+}
+
+//UnmarshalJSON decodes a DFA written with the schema described by JSONVersion and validates it
+func (dfa *DFA) UnmarshalJSON(data []byte) error {
+	var decoded jsonDFA
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if decoded.Version != JSONVersion {
+		return fmt.Errorf("unsupported DFA JSON version %d", decoded.Version)
+	}
+	newDFA := New()
+	newDFA.Alphabet = append(newDFA.Alphabet, decoded.Alphabet...)
+	newDFA.States = append(newDFA.States, decoded.States...)
+	newDFA.StartStates = append(newDFA.StartStates, decoded.Start...)
+	newDFA.AcceptStates = append(newDFA.AcceptStates, decoded.Accept...)
+	for _, state := range newDFA.States {
+		newDFA.Transitions[state] = make(map[string]int, 0)
+		if state >= newDFA.nextState {
+			newDFA.nextState = state + 1
+		}
+	}
+	for _, transition := range decoded.Transitions {
+		if newDFA.Transitions[transition.From] == nil {
+			newDFA.Transitions[transition.From] = make(map[string]int, 0)
+		}
+		if _, exists := newDFA.Transitions[transition.From][transition.Symbol]; exists {
+			return fmt.Errorf("state %d has more than one transition on symbol %q", transition.From, transition.Symbol)
+		}
+		newDFA.AddTransition(transition.From, transition.Symbol, transition.To)
+	}
+	for _, acceptLabels := range decoded.Labels {
+		newDFA.AcceptLabels[acceptLabels.State] = acceptLabels.Labels
+	}
+	if err := newDFA.Validate(); err != nil {
+		return err
+	}
+	*dfa = *newDFA
+	return nil
+}
@@ -0,0 +1,206 @@
+package dfa
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+	"github.com/ChristopherCamara/finiteAutomata/nfa"
+)
+
+//DefaultLazyCacheSize is the cache budget in bytes used when NewLazy is given none
+const DefaultLazyCacheSize = 1 << 20
+
+//lazyMaxFlushes is how often the cache may be flushed during a single match before falling back to NFA simulation
+const lazyMaxFlushes = 8
+
+//rough memory cost of cached states and transitions, used to keep the cache within budget
+const (
+	lazyStateCost      = 64
+	lazyStateEntryCost = 8
+	lazyTransitionCost = 48
+)
+
+//LazyDFA determinises a NFA on demand while matching
+//
+//Subsets of NFA states are only built when the input reaches them and are kept
+//in a cache of bounded size. When the cache fills up it is flushed, and when a
+//single match flushes it too often the rest of that input is matched by
+//simulating the NFA directly
+//
+//A LazyDFA is safe for concurrent use, matches share the cache so they hold a
+//lock and run one at a time
+type LazyDFA struct {
+	NFA             *nfa.NFA
+	mutex           sync.Mutex
+	epsilonClosures map[int][]int
+	cacheSize       int
+	cacheUsed       int
+	cache           map[string]*lazyState
+	start           []int
+	flushes         int
+	fallbacks       int
+}
+
+type lazyState struct {
+	states      []int
+	accept      bool
+	transitions map[string]*lazyState
+}
+
+//NewLazy returns ready to use *LazyDFA for a NFA, cacheSize is the cache budget in bytes
+func NewLazy(NFA *nfa.NFA, cacheSize int) *LazyDFA {
+	if cacheSize <= 0 {
+		cacheSize = DefaultLazyCacheSize
+	}
+	lazy := new(LazyDFA)
+	lazy.NFA = NFA
+	lazy.epsilonClosures = NFA.GetEpsilonClosures()
+	lazy.cacheSize = cacheSize
+	lazy.cache = make(map[string]*lazyState)
+	lazy.start = make([]int, 0)
+	for _, startState := range NFA.StartStates {
+		lazy.start = lazy.addClosure(lazy.start, startState)
+	}
+	sort.Ints(lazy.start)
+	return lazy
+}
+
+//Accepts reports whether input, read one rune per symbol, is in the language of the NFA
+func (lazy *LazyDFA) Accepts(input string) bool {
+	lazy.mutex.Lock()
+	defer lazy.mutex.Unlock()
+	matchFlushes := 0
+	current := lazy.lookup(lazy.start)
+	for position := 0; position < len(input); {
+		symbol, size := DecodeSymbol(input[position:])
+		position += size
+		next, cached := current.transitions[symbol]
+		if !cached {
+			nextStates := lazy.step(current.states, symbol)
+			if lazy.cacheUsed+lazy.cost(nextStates) > lazy.cacheSize {
+				lazy.flush()
+				matchFlushes++
+				if matchFlushes > lazyMaxFlushes {
+					lazy.fallbacks++
+					return lazy.simulate(nextStates, input[position:])
+				}
+				current = lazy.lookup(current.states)
+			}
+			next = lazy.lookup(nextStates)
+			current.transitions[symbol] = next
+			lazy.cacheUsed += lazyTransitionCost
+		}
+		if len(next.states) == 0 {
+			return false
+		}
+		current = next
+	}
+	return current.accept
+}
+
+//CacheFlushes returns how often the state cache has been flushed
+func (lazy *LazyDFA) CacheFlushes() int {
+	lazy.mutex.Lock()
+	defer lazy.mutex.Unlock()
+	return lazy.flushes
+}
+
+//Fallbacks returns how many matches finished by simulating the NFA
+func (lazy *LazyDFA) Fallbacks() int {
+	lazy.mutex.Lock()
+	defer lazy.mutex.Unlock()
+	return lazy.fallbacks
+}
+
+//CachedStates returns how many subsets are currently cached
+func (lazy *LazyDFA) CachedStates() int {
+	lazy.mutex.Lock()
+	defer lazy.mutex.Unlock()
+	return len(lazy.cache)
+}
+
+//cost of caching a transition into states, the subset itself is only charged when it is not cached yet
+func (lazy *LazyDFA) cost(states []int) int {
+	if _, cached := lazy.cache[subsetKey(states)]; cached {
+		return lazyTransitionCost
+	}
+	return lazyStateCost + lazyStateEntryCost*len(states) + lazyTransitionCost
+}
+
+func (lazy *LazyDFA) flush() {
+	lazy.cache = make(map[string]*lazyState)
+	lazy.cacheUsed = 0
+	lazy.flushes++
+}
+
+//lookup the cached state for a sorted set of NFA states, adding it if it is missing
+func (lazy *LazyDFA) lookup(states []int) *lazyState {
+	key := subsetKey(states)
+	if state, exists := lazy.cache[key]; exists {
+		return state
+	}
+	state := &lazyState{states: states, transitions: make(map[string]*lazyState)}
+	for _, nfaState := range states {
+		if intArray.IndexOf(nfaState, lazy.NFA.AcceptStates) != -1 {
+			state.accept = true
+			break
+		}
+	}
+	lazy.cache[key] = state
+	lazy.cacheUsed += lazyStateCost + lazyStateEntryCost*len(states)
+	return state
+}
+
+//step from a set of NFA states over symbol, the result is sorted and epsilon closed
+func (lazy *LazyDFA) step(states []int, symbol string) []int {
+	nextStates := make([]int, 0)
+	for _, state := range states {
+		for _, transitionState := range lazy.NFA.Transitions[state][symbol] {
+			nextStates = lazy.addClosure(nextStates, transitionState)
+		}
+	}
+	sort.Ints(nextStates)
+	return nextStates
+}
+
+func (lazy *LazyDFA) addClosure(states []int, state int) []int {
+	for _, closureState := range lazy.epsilonClosures[state] {
+		if intArray.IndexOf(closureState, states) == -1 {
+			states = append(states, closureState)
+		}
+	}
+	return states
+}
+
+//simulate the NFA over the rest of the input without caching anything
+func (lazy *LazyDFA) simulate(states []int, input string) bool {
+	for position := 0; position < len(input); {
+		if len(states) == 0 {
+			return false
+		}
+		symbol, size := DecodeSymbol(input[position:])
+		position += size
+		states = lazy.step(states, symbol)
+	}
+	for _, state := range states {
+		if intArray.IndexOf(state, lazy.NFA.AcceptStates) != -1 {
+			return true
+		}
+	}
+	return false
+}
+
+//subsetKey identifies a sorted set of NFA states, it is shared with the subset construction
+func subsetKey(states []int) string {
+	var key strings.Builder
+	for index, state := range states {
+		if index != 0 {
+			key.WriteByte(',')
+		}
+		key.WriteString(strconv.Itoa(state))
+	}
+	return key.String()
+}
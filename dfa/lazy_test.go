@@ -0,0 +1,36 @@
+package dfa
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	regexparser "github.com/ChristopherCamara/finiteAutomata/regexParser"
+)
+
+//run with -race, every goroutine fills and flushes the same cache
+func TestLazyDFAConcurrent(t *testing.T) {
+	const regex = "(a|b)*a(a|b)(a|b)(a|b)"
+	parser := new(regexparser.RegexParser)
+	expected := FromNFA(parser.ParseToNFA(regex))
+	lazy := NewLazy(parser.ParseToNFA(regex), 512)
+	toSymbols := strings.NewReplacer("0", "a", "1", "b")
+	var group sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		group.Add(1)
+		go func(worker int) {
+			defer group.Done()
+			for i := 0; i < 200; i++ {
+				input := toSymbols.Replace(strconv.FormatInt(int64(worker*1000+i), 2))
+				if accepted := lazy.Accepts(input); accepted != expected.Accepts(input) {
+					t.Errorf("%q: expected %t, got %t", input, !accepted, accepted)
+				}
+			}
+		}(worker)
+	}
+	group.Wait()
+	if lazy.CacheFlushes() == 0 {
+		t.Errorf("expected the cache to be flushed")
+	}
+}
@@ -0,0 +1,44 @@
+package dfa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ChristopherCamara/finiteAutomata/nfa"
+)
+
+//ErrStateLimitExceeded is returned once a construction needs more states than Limits allows
+var ErrStateLimitExceeded = errors.New("state limit exceeded")
+
+//Limits bounds the resources used by FromNFAContext and ReverseContext
+type Limits struct {
+	//MaxStates is the most DFA states a construction may create, 0 means no limit
+	MaxStates int
+}
+
+func stateLimitError(maxStates int) error {
+	return fmt.Errorf("%w: more than %d states", ErrStateLimitExceeded, maxStates)
+}
+
+//FromNFAContext create a DFA from a NFA, stopping with ctx.Err() or ErrStateLimitExceeded
+func FromNFAContext(ctx context.Context, NFA *nfa.NFA, limits Limits) (*DFA, error) {
+	return fromNFA(ctx, NFA, limits, nil)
+}
+
+//MinimizeContext minimizes a DFA like Minimize, stopping with ctx.Err() once ctx is done
+//
+//The DFA is left untouched when minimizing is stopped
+func (dfa *DFA) MinimizeContext(ctx context.Context) error {
+	minDFA := dfa.clone()
+	if err := minDFA.minimize(ctx, nil); err != nil {
+		return err
+	}
+	*dfa = *minDFA
+	return nil
+}
+
+//ReverseContext reverses a DFA like Reverse, stopping with ctx.Err() or ErrStateLimitExceeded
+func (dfa *DFA) ReverseContext(ctx context.Context, limits Limits) (*DFA, error) {
+	return dfa.reverse(ctx, limits)
+}
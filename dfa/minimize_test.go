@@ -0,0 +1,113 @@
+package dfa
+
+import (
+	"testing"
+
+	"github.com/ChristopherCamara/finiteAutomata/nfa"
+	regexparser "github.com/ChristopherCamara/finiteAutomata/regexParser"
+)
+
+var minimizeRegexes = []string{
+	"",
+	"a",
+	"ab",
+	"a|b",
+	"b|ab",
+	"a*",
+	"a*b*",
+	"(a|b)*",
+	"(a|b)*abb",
+	"(ab|a)*",
+	"(a|b)*a(a|b)(a|b)",
+	"aa*|a*a",
+	"(aa|aaa)*",
+	"((a|b)(a|b))*|a(b|c)*",
+}
+
+//emptyLanguageNFA has an accept state that can never be reached
+func emptyLanguageNFA() *nfa.NFA {
+	NFA := nfa.New()
+	NFA.Alphabet = []string{"a", "b"}
+	startState := NFA.AddState(true, false)
+	acceptState := NFA.AddState(false, true)
+	NFA.AddTransition(startState, "a", startState)
+	NFA.AddTransition(acceptState, "b", startState)
+	return NFA
+}
+
+//minimizeInputs holds every regex as a partial DFA and completed with a sink state
+func minimizeInputs() map[string]*DFA {
+	inputs := make(map[string]*DFA)
+	NFAs := map[string]*nfa.NFA{"empty language": emptyLanguageNFA()}
+	for _, regex := range minimizeRegexes {
+		parser := new(regexparser.RegexParser)
+		NFAs["regex "+regex] = parser.ParseToNFA(regex)
+	}
+	for name, NFA := range NFAs {
+		inputs[name+" partial"] = FromNFA(NFA)
+		complete := FromNFA(NFA)
+		complete.ExtendAlphabet()
+		inputs[name+" complete"] = complete
+		//a symbol no state moves on gives every input an explicit dead state
+		extended := FromNFA(NFA)
+		extended.ExtendAlphabet("z")
+		inputs[name+" extended"] = extended
+	}
+	return inputs
+}
+
+//words over alphabet up to the given length
+func words(alphabet []string, length int) []string {
+	result := []string{""}
+	for i := 0; i < len(result); i++ {
+		if len(result[i]) == length {
+			continue
+		}
+		for _, symbol := range alphabet {
+			result = append(result, result[i]+symbol)
+		}
+	}
+	return result
+}
+
+func TestMinimizeBrzozowskiMatchesMinimize(t *testing.T) {
+	for name, input := range minimizeInputs() {
+		expected := input.clone()
+		expected.Minimize()
+		actual := input.clone()
+		actual.MinimizeBrzozowski()
+		if len(actual.States) != len(expected.States) {
+			t.Errorf("%s: MinimizeBrzozowski has %d states, Minimize has %d", name, len(actual.States), len(expected.States))
+			continue
+		}
+		if !Isomorphic(actual, expected) {
+			t.Errorf("%s: MinimizeBrzozowski is not isomorphic to Minimize", name)
+		}
+		for _, word := range words(input.Alphabet, 5) {
+			if actual.Accepts(word) != input.Accepts(word) {
+				t.Errorf("%s: MinimizeBrzozowski changed the language on %q", name, word)
+			}
+		}
+		//minimizing again must neither fail nor change anything
+		actual.Minimize()
+		if !Isomorphic(actual, expected) {
+			t.Errorf("%s: Minimize after MinimizeBrzozowski is not isomorphic to Minimize", name)
+		}
+	}
+}
+
+func TestMinimizeBrzozowskiEmptyLanguage(t *testing.T) {
+	empty := FromNFA(emptyLanguageNFA())
+	empty.MinimizeBrzozowski()
+	if len(empty.StartStates) != 1 || len(empty.States) != 1 {
+		t.Fatalf("expected a single start state, got states %v and start states %v", empty.States, empty.StartStates)
+	}
+	if len(empty.AcceptStates) != 0 {
+		t.Errorf("expected no accept states, got %v", empty.AcceptStates)
+	}
+	for _, input := range []string{"", "a", "ab"} {
+		if empty.Accepts(input) {
+			t.Errorf("empty language accepts %q", input)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package dfa
+
+import (
+	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+	"github.com/ChristopherCamara/finiteAutomata/nfa"
+)
+
+//productPairs explores the product of a and b from the given pairs of states,
+//following the symbols on which both have a transition. Returns the pairs in
+//the order they were found together with the predecessors of every pair
+func productPairs(a, b *DFA, starts [][2]int) ([][2]int, map[[2]int][][2]int) {
+	pairs := make([][2]int, 0, len(starts))
+	visited := make(map[[2]int]bool)
+	predecessors := make(map[[2]int][][2]int)
+	for _, start := range starts {
+		if !visited[start] {
+			visited[start] = true
+			pairs = append(pairs, start)
+		}
+	}
+	for i := 0; i < len(pairs); i++ {
+		pair := pairs[i]
+		for _, symbol := range a.sortedSymbols(pair[0]) {
+			secondState, exists := b.Transitions[pair[1]][symbol]
+			if !exists {
+				continue
+			}
+			next := [2]int{a.Transitions[pair[0]][symbol], secondState}
+			predecessors[next] = append(predecessors[next], pair)
+			if !visited[next] {
+				visited[next] = true
+				pairs = append(pairs, next)
+			}
+		}
+	}
+	return pairs, predecessors
+}
+
+//toNFA copies the states and transitions of a DFA into a NFA
+func (dfa *DFA) toNFA() (*nfa.NFA, map[int]int) {
+	NFA := nfa.New()
+	NFA.Alphabet = append(NFA.Alphabet, dfa.Alphabet...)
+	stateMappings := make(map[int]int)
+	for _, state := range dfa.States {
+		stateMappings[state] = NFA.AddState(false, intArray.IndexOf(state, dfa.AcceptStates) != -1)
+	}
+	for _, state := range dfa.States {
+		for _, symbol := range dfa.sortedSymbols(state) {
+			NFA.AddTransition(stateMappings[state], symbol, stateMappings[dfa.Transitions[state][symbol]])
+		}
+	}
+	return NFA, stateMappings
+}
+
+//RightQuotient returns a DFA for the words x such that xy is accepted by a for some y accepted by b
+//
+//The result keeps the states and transitions of a, a state accepts when some
+//word of b leads from it to an accept state of a. Accept labels are dropped
+func RightQuotient(a, b *DFA) *DFA {
+	quotient := a.clone()
+	quotient.AcceptStates = make([]int, 0)
+	quotient.AcceptLabels = make(map[int][]int)
+	if len(b.StartStates) == 0 {
+		return quotient
+	}
+	starts := make([][2]int, 0, len(a.States))
+	for _, state := range a.States {
+		starts = append(starts, [2]int{state, b.StartStates[0]})
+	}
+	pairs, predecessors := productPairs(a, b, starts)
+	//walk back from the pairs where both accept
+	accepting := make(map[[2]int]bool)
+	queue := make([][2]int, 0)
+	for _, pair := range pairs {
+		if intArray.IndexOf(pair[0], a.AcceptStates) != -1 && intArray.IndexOf(pair[1], b.AcceptStates) != -1 {
+			accepting[pair] = true
+			queue = append(queue, pair)
+		}
+	}
+	for len(queue) != 0 {
+		pair := queue[0]
+		queue = queue[1:]
+		for _, predecessor := range predecessors[pair] {
+			if !accepting[predecessor] {
+				accepting[predecessor] = true
+				queue = append(queue, predecessor)
+			}
+		}
+	}
+	for _, start := range starts {
+		if accepting[start] {
+			quotient.AcceptStates = append(quotient.AcceptStates, start[0])
+		}
+	}
+	return quotient
+}
+
+//LeftQuotient returns a DFA for the words y such that xy is accepted by a for some x accepted by b
+//
+//Every state of a reached by a word of b becomes a start state, the result is
+//determinised from there. Accept labels are dropped
+func LeftQuotient(a, b *DFA) *DFA {
+	NFA, stateMappings := a.toNFA()
+	if len(a.StartStates) != 0 && len(b.StartStates) != 0 {
+		pairs, _ := productPairs(a, b, [][2]int{{a.StartStates[0], b.StartStates[0]}})
+		for _, pair := range pairs {
+			if intArray.IndexOf(pair[1], b.AcceptStates) != -1 && intArray.IndexOf(stateMappings[pair[0]], NFA.StartStates) == -1 {
+				NFA.StartStates = append(NFA.StartStates, stateMappings[pair[0]])
+			}
+		}
+	}
+	return FromNFA(NFA)
+}
+
+//Prefixes returns a DFA for every prefix of the words accepted by a DFA
+//
+//These are the inputs that can still be completed to an accepted word, each
+//state from which an accept state can be reached becomes accepting
+func (dfa *DFA) Prefixes() *DFA {
+	prefixes := dfa.clone()
+	prefixes.AcceptStates = make([]int, 0)
+	prefixes.AcceptLabels = make(map[int][]int)
+	live := dfa.liveStates()
+	for _, state := range dfa.States {
+		if live[state] {
+			prefixes.AcceptStates = append(prefixes.AcceptStates, state)
+		}
+	}
+	return prefixes
+}
+
+//Suffixes returns a DFA for every suffix of the words accepted by a DFA
+//
+//Every state reachable from the start state becomes a start state and the
+//result is determinised from there
+func (dfa *DFA) Suffixes() *DFA {
+	NFA, stateMappings := dfa.toNFA()
+	if len(dfa.StartStates) != 0 {
+		for _, state := range dfa.reachableStates() {
+			NFA.StartStates = append(NFA.StartStates, stateMappings[state])
+		}
+	}
+	return FromNFA(NFA)
+}
+
+//reachableStates of a DFA in breadth first order from the start state
+func (dfa *DFA) reachableStates() []int {
+	states := []int{dfa.StartStates[0]}
+	visited := map[int]bool{dfa.StartStates[0]: true}
+	for i := 0; i < len(states); i++ {
+		for _, symbol := range dfa.sortedSymbols(states[i]) {
+			targetState := dfa.Transitions[states[i]][symbol]
+			if !visited[targetState] {
+				visited[targetState] = true
+				states = append(states, targetState)
+			}
+		}
+	}
+	return states
+}
+
+//Infixes returns a DFA for every factor of the words accepted by a DFA, the suffixes of its prefixes
+func (dfa *DFA) Infixes() *DFA {
+	return dfa.Prefixes().Suffixes()
+}
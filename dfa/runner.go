@@ -0,0 +1,186 @@
+package dfa
+
+import (
+	"bufio"
+	"io"
+	"unicode/utf8"
+)
+
+//StreamMode decides how a stream is split into symbols
+type StreamMode int
+
+const (
+	//Runes reads the stream as UTF-8, one rune per symbol
+	Runes StreamMode = iota
+	//Bytes reads the stream one byte per symbol
+	Bytes
+)
+
+//streamBufferSize is the size of each chunk read from a stream
+const streamBufferSize = 4096
+
+//Runner steps through a DFA one symbol at a time
+type Runner struct {
+	dfa   *DFA
+	state int
+	dead  bool
+}
+
+//NewRunner returns a *Runner positioned at the start state of a DFA
+func (dfa *DFA) NewRunner() *Runner {
+	runner := &Runner{dfa: dfa}
+	runner.Reset()
+	return runner
+}
+
+//Reset the runner back to the start state
+func (r *Runner) Reset() {
+	if len(r.dfa.StartStates) == 0 {
+		r.state = -1
+		r.dead = true
+		return
+	}
+	r.state = r.dfa.StartStates[0]
+	r.dead = false
+}
+
+//Step the runner over symbol, returns false once no transition is left to follow
+func (r *Runner) Step(symbol string) bool {
+	if r.dead {
+		return false
+	}
+	targetState, exists := r.dfa.Transitions[r.state][symbol]
+	if !exists {
+		r.state = -1
+		r.dead = true
+		return false
+	}
+	r.state = targetState
+	return true
+}
+
+//State the runner is currently in, -1 once the runner is dead
+func (r *Runner) State() int {
+	return r.state
+}
+
+//Dead reports whether the runner followed a missing transition
+//
+//A complete DFA never dies, its runner keeps moving inside a sink state instead
+func (r *Runner) Dead() bool {
+	return r.dead
+}
+
+//Accepting reports whether the input seen so far is accepted
+func (r *Runner) Accepting() bool {
+	if r.dead {
+		return false
+	}
+	for _, acceptState := range r.dfa.AcceptStates {
+		if acceptState == r.state {
+			return true
+		}
+	}
+	return false
+}
+
+//AcceptLabels of the current state, nil if the input seen so far is not accepted
+func (r *Runner) AcceptLabels() []int {
+	if !r.Accepting() {
+		return nil
+	}
+	return r.dfa.AcceptLabels[r.state]
+}
+
+//Accepts reports whether input, read one rune per symbol, is in the language of a DFA
+func (dfa *DFA) Accepts(input string) bool {
+	runner := dfa.NewRunner()
+	for position := 0; position < len(input); {
+		symbol, size := DecodeSymbol(input[position:])
+		position += size
+		if !runner.Step(symbol) {
+			return false
+		}
+	}
+	return runner.Accepting()
+}
+
+//DecodeSymbol splits the first symbol off input read one rune per symbol
+//
+//A byte that does not start valid UTF-8 becomes a symbol of its own, which
+//never equals a rune symbol, not even "\uFFFD". Everything reading runes
+//from strings or streams follows this so a DFA gives the same answers
+func DecodeSymbol(input string) (string, int) {
+	current, size := utf8.DecodeRuneInString(input)
+	if current == utf8.RuneError && size == 1 {
+		return input[:1], 1
+	}
+	return input[:size], size
+}
+
+//decodeLastSymbol splits the last symbol off input like DecodeSymbol
+func decodeLastSymbol(input string) (string, int) {
+	_, size := utf8.DecodeLastRuneInString(input)
+	return input[len(input)-size:], size
+}
+
+//AcceptsReader reports whether the whole stream is in the language of a DFA
+//
+//The stream is consumed in fixed size chunks and is never held in memory as a whole
+func (dfa *DFA) AcceptsReader(reader io.Reader, mode StreamMode) (bool, error) {
+	return dfa.ScanReader(reader, mode, nil)
+}
+
+//ScanReader runs a DFA over a stream and reports whether the whole stream is accepted
+//
+//If callback is not nil it is called after every symbol with the byte offset
+//just past that symbol and whether the input up to there is accepted.
+//Returning false from callback stops the scan early without an error, the
+//result then reports whether the input read up to there is accepted rather
+//than the whole stream. Reading also stops as soon as no further input can
+//lead to acceptance, whether by a missing transition or by entering a sink state.
+//The symbol that got there is still reported, callback is not called for the
+//rest of the stream since every later offset would be rejected too.
+func (dfa *DFA) ScanReader(reader io.Reader, mode StreamMode, callback func(offset int64, accepting bool) bool) (bool, error) {
+	runner := dfa.NewRunner()
+	live := dfa.liveStates()
+	bufferedReader := bufio.NewReaderSize(reader, streamBufferSize)
+	offset := int64(0)
+	for !runner.Dead() && live[runner.State()] {
+		var symbol string
+		if mode == Bytes {
+			current, err := bufferedReader.ReadByte()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return false, err
+			}
+			symbol = string([]byte{current})
+			offset++
+		} else {
+			current, size, err := bufferedReader.ReadRune()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return false, err
+			}
+			if current == utf8.RuneError && size == 1 {
+				//the invalid byte itself is the symbol, like in DecodeSymbol
+				bufferedReader.UnreadRune()
+				invalid, _ := bufferedReader.ReadByte()
+				symbol = string([]byte{invalid})
+			} else {
+				symbol = string(current)
+			}
+			offset += int64(size)
+		}
+		runner.Step(symbol)
+		if callback != nil && !callback(offset, runner.Accepting()) {
+			return runner.Accepting(), nil
+		}
+	}
+	if runner.Dead() || !live[runner.State()] {
+		return false, nil
+	}
+	return runner.Accepting(), nil
+}
@@ -0,0 +1,48 @@
+package dfa
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	regexparser "github.com/ChristopherCamara/finiteAutomata/regexParser"
+)
+
+func TestScanReaderCallback(t *testing.T) {
+	type report struct {
+		offset    int64
+		accepting bool
+	}
+	parser := new(regexparser.RegexParser)
+	partial := FromNFA(parser.ParseToNFA("ab*"))
+	complete := FromNFA(parser.ParseToNFA("ab*"))
+	complete.ExtendAlphabet("x")
+	for name, current := range map[string]*DFA{"missing transition": partial, "sink state": complete} {
+		reports := make([]report, 0)
+		accepted, err := current.ScanReader(strings.NewReader("abxbb"), Bytes, func(offset int64, accepting bool) bool {
+			reports = append(reports, report{offset, accepting})
+			return true
+		})
+		if err != nil || accepted {
+			t.Errorf("%s: expected false, got %t, %v", name, accepted, err)
+		}
+		//the x is reported, the b's after it are not
+		expected := []report{{1, true}, {2, true}, {3, false}}
+		if !reflect.DeepEqual(reports, expected) {
+			t.Errorf("%s: expected %v, got %v", name, expected, reports)
+		}
+	}
+}
+
+func TestScanReaderStopEarly(t *testing.T) {
+	parser := new(regexparser.RegexParser)
+	current := FromNFA(parser.ParseToNFA("ab*"))
+	calls := 0
+	accepted, err := current.ScanReader(strings.NewReader("abbbx"), Runes, func(offset int64, accepting bool) bool {
+		calls++
+		return offset < 2
+	})
+	if err != nil || !accepted || calls != 2 {
+		t.Errorf("expected true after 2 calls, got %t after %d calls, %v", accepted, calls, err)
+	}
+}
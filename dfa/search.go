@@ -0,0 +1,153 @@
+package dfa
+
+import (
+	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+	"github.com/ChristopherCamara/finiteAutomata/nfa"
+)
+
+//Searcher finds leftmost-longest matches of a DFA inside longer inputs
+//
+//A Searcher keeps the forward DFA together with a DFA for the reversed
+//language prefixed by any number of symbols, so it should be reused when
+//searching more than one input
+type Searcher struct {
+	forward *DFA
+	reverse *DFA
+}
+
+//NewSearcher returns ready to use *Searcher for the language of a DFA
+func NewSearcher(dfa *DFA) *Searcher {
+	reverse := dfa.Reverse()
+	NFA := nfa.New()
+	NFA.Alphabet = reverse.Alphabet
+	loopState := NFA.AddState(true, false)
+	for _, symbol := range reverse.Alphabet {
+		NFA.AddTransition(loopState, symbol, loopState)
+	}
+	stateMappings := make(map[int]int)
+	for _, state := range reverse.States {
+		stateMappings[state] = NFA.AddState(false, false)
+		if intArray.IndexOf(state, reverse.AcceptStates) != -1 {
+			NFA.AcceptStates = append(NFA.AcceptStates, stateMappings[state])
+		}
+	}
+	for _, state := range reverse.States {
+		for _, symbol := range reverse.sortedSymbols(state) {
+			NFA.AddTransition(stateMappings[state], symbol, stateMappings[reverse.Transitions[state][symbol]])
+		}
+	}
+	for _, startState := range reverse.StartStates {
+		NFA.AddEpsilonTransition(loopState, stateMappings[startState])
+	}
+	return &Searcher{forward: dfa, reverse: FromNFA(NFA)}
+}
+
+//FindIndex of the leftmost-longest match in input, -1, -1 if there is none
+//
+//Offsets are byte offsets into input and input is read one rune per symbol
+func (s *Searcher) FindIndex(input string) (start, end int) {
+	matches := s.FindAllIndex(input, 1)
+	if len(matches) == 0 {
+		return -1, -1
+	}
+	return matches[0][0], matches[0][1]
+}
+
+//FindAllIndex of successive non-overlapping leftmost-longest matches in input
+//
+//If n >= 0 at most n matches are returned, like regexp.FindAllIndex an empty
+//match directly after the previous match is ignored
+func (s *Searcher) FindAllIndex(input string, n int) [][]int {
+	matches := make([][]int, 0)
+	if n == 0 {
+		return matches
+	}
+	starts := s.matchStarts(input)
+	previousEnd := -1
+	position := 0
+	for position <= len(input) {
+		if !starts[position] {
+			position = nextPosition(input, position)
+			continue
+		}
+		end := s.MatchAt(input, position)
+		if end == position && position == previousEnd {
+			position = nextPosition(input, position)
+			continue
+		}
+		matches = append(matches, []int{position, end})
+		if len(matches) == n {
+			break
+		}
+		previousEnd = end
+		if end == position {
+			position = nextPosition(input, position)
+		} else {
+			position = end
+		}
+	}
+	return matches
+}
+
+//MatchAt returns the end of the longest match starting exactly at byte offset start, -1 if there is none
+func (s *Searcher) MatchAt(input string, start int) int {
+	return s.forward.MatchAt(input, start)
+}
+
+//matchStarts marks every byte offset of input at which some match begins
+func (s *Searcher) matchStarts(input string) []bool {
+	starts := make([]bool, len(input)+1)
+	runner := s.reverse.NewRunner()
+	starts[len(input)] = runner.Accepting()
+	for position := len(input); position > 0; {
+		symbol, size := decodeLastSymbol(input[:position])
+		position -= size
+		if !runner.Step(symbol) {
+			//a symbol outside the alphabet can only be consumed by the leading loop
+			runner.Reset()
+		}
+		starts[position] = runner.Accepting()
+	}
+	return starts
+}
+
+func nextPosition(input string, position int) int {
+	if position >= len(input) {
+		return position + 1
+	}
+	_, size := DecodeSymbol(input[position:])
+	return position + size
+}
+
+//FindIndex of the leftmost-longest match of a DFA in input, see Searcher.FindIndex
+func (dfa *DFA) FindIndex(input string) (start, end int) {
+	return NewSearcher(dfa).FindIndex(input)
+}
+
+//FindAllIndex of the leftmost-longest matches of a DFA in input, see Searcher.FindAllIndex
+func (dfa *DFA) FindAllIndex(input string, n int) [][]int {
+	return NewSearcher(dfa).FindAllIndex(input, n)
+}
+
+//MatchAt returns the end of the longest match of a DFA starting exactly at byte offset start, -1 if there is none
+func (dfa *DFA) MatchAt(input string, start int) int {
+	if start < 0 || start > len(input) {
+		return -1
+	}
+	runner := dfa.NewRunner()
+	end := -1
+	if runner.Accepting() {
+		end = start
+	}
+	for offset := start; offset < len(input); {
+		symbol, size := DecodeSymbol(input[offset:])
+		offset += size
+		if !runner.Step(symbol) {
+			break
+		}
+		if runner.Accepting() {
+			end = offset
+		}
+	}
+	return end
+}
@@ -0,0 +1,113 @@
+package dfa
+
+import (
+	"math/rand"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ChristopherCamara/finiteAutomata/nfa"
+	regexparser "github.com/ChristopherCamara/finiteAutomata/regexParser"
+)
+
+func searcherFor(regex string) *Searcher {
+	parser := new(regexparser.RegexParser)
+	return NewSearcher(FromNFA(parser.ParseToNFA(regex)))
+}
+
+func TestFindAllIndex(t *testing.T) {
+	for _, current := range []struct {
+		regex    string
+		input    string
+		n        int
+		expected [][]int
+	}{
+		{"ab*", "xxabbbyab", -1, [][]int{{2, 6}, {7, 9}}},
+		//matches do not overlap, the second "aba" starts inside the first
+		{"aba", "ababa", -1, [][]int{{0, 3}}},
+		{"a|ab|abc", "abcab", -1, [][]int{{0, 3}, {3, 5}}},
+		//an empty match right after a match is skipped
+		{"a*", "baaac", -1, [][]int{{0, 0}, {1, 4}, {5, 5}}},
+		{"a*", "", -1, [][]int{{0, 0}}},
+		{"ab", "abababab", 2, [][]int{{0, 2}, {2, 4}}},
+		{"ab", "abab", 0, [][]int{}},
+		//offsets count bytes, not runes
+		{"a", "éaé", -1, [][]int{{2, 3}}},
+		{"c", "ab", -1, [][]int{}},
+	} {
+		if matches := searcherFor(current.regex).FindAllIndex(current.input, current.n); !reflect.DeepEqual(matches, current.expected) {
+			t.Errorf("%s in %q: expected %v, got %v", current.regex, current.input, current.expected, matches)
+		}
+	}
+}
+
+func TestFindIndexAndMatchAt(t *testing.T) {
+	searcher := searcherFor("(a|b)*c")
+	if start, end := searcher.FindIndex("xxabacbc"); start != 2 || end != 6 {
+		t.Errorf("expected the leftmost-longest match 2, 6, got %d, %d", start, end)
+	}
+	if start, end := searcher.FindIndex("xxab"); start != -1 || end != -1 {
+		t.Errorf("expected no match, got %d, %d", start, end)
+	}
+	for start, expected := range map[int]int{-1: -1, 0: 3, 1: 3, 2: 3, 3: 5, 4: 5, 5: -1, 6: -1} {
+		if end := searcher.MatchAt("abcbc", start); end != expected {
+			t.Errorf("MatchAt %d: expected %d, got %d", start, expected, end)
+		}
+	}
+}
+
+//the leftmost-longest matches must agree with regexp in POSIX mode
+func TestFindAllIndexMatchesRegexp(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	symbols := []string{"a", "b", "c", "é"}
+	for _, regex := range []string{"a", "ab*", "(a|b)*c", "a*", "(ab|a)(b|c)*", "b(a|c)*b", "c*|ab"} {
+		searcher := searcherFor(regex)
+		expected := regexp.MustCompilePOSIX(regex)
+		for i := 0; i < 200; i++ {
+			var input strings.Builder
+			for j := random.Intn(12); j > 0; j-- {
+				input.WriteString(symbols[random.Intn(len(symbols))])
+			}
+			want := expected.FindAllStringIndex(input.String(), -1)
+			if want == nil {
+				want = [][]int{}
+			}
+			if got := searcher.FindAllIndex(input.String(), -1); !reflect.DeepEqual(got, want) {
+				t.Fatalf("%s in %q: expected %v, got %v", regex, input.String(), want, got)
+			}
+		}
+	}
+}
+
+//invalid UTF-8 must never match "\uFFFD", whichever way the input is read
+func TestInvalidUTF8(t *testing.T) {
+	replacement := New()
+	startState := replacement.AddState(true, false)
+	acceptState := replacement.AddState(false, true)
+	replacement.Alphabet = []string{"\uFFFD"}
+	replacement.AddTransition(startState, "\uFFFD", acceptState)
+	NFA := nfa.New()
+	NFA.Alphabet = []string{"\uFFFD"}
+	NFA.AddState(true, false)
+	NFA.AddState(false, true)
+	NFA.AddTransition(0, "\uFFFD", 1)
+	lazy := NewLazy(NFA, 1024)
+	for input, expected := range map[string]bool{"\uFFFD": true, "\xff": false, "\xef\xbf": false, "\xef": false} {
+		if replacement.Accepts(input) != expected {
+			t.Errorf("Accepts %q: expected %t", input, expected)
+		}
+		if accepted, err := replacement.AcceptsReader(strings.NewReader(input), Runes); err != nil || accepted != expected {
+			t.Errorf("AcceptsReader %q: expected %t, got %t, %v", input, expected, accepted, err)
+		}
+		if lazy.Accepts(input) != expected {
+			t.Errorf("LazyDFA %q: expected %t", input, expected)
+		}
+		if end := replacement.MatchAt(input, 0); (end == len(input)) != expected {
+			t.Errorf("MatchAt %q: expected %t, got end %d", input, expected, end)
+		}
+	}
+	if start, end := replacement.FindIndex("\xff\xef\xbf\uFFFD"); start != 3 || end != 6 {
+		t.Errorf("expected the match 3, 6 after the invalid bytes, got %d, %d", start, end)
+	}
+}
@@ -0,0 +1,210 @@
+package dfa
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+	"github.com/ChristopherCamara/finiteAutomata/internal/stringArray"
+)
+
+//deadState stands for the missing target of a transition while filling the table
+const deadState = -1
+
+//DistinguishabilityTable is the table filled in by the table-filling (Myhill–Nerode) algorithm
+//
+//For every pair of distinguishable states it keeps a shortest word leading
+//them to states that differ in acceptance or in their accept labels. The word
+//is accepted from exactly one of them, unless both accept it with different
+//labels, so states differing only in their labels are told apart by ε
+type DistinguishabilityTable struct {
+	States    []int
+	witnesses map[[2]int][]string
+}
+
+func pairKey(first, second int) [2]int {
+	if first > second {
+		first, second = second, first
+	}
+	return [2]int{first, second}
+}
+
+//TableFilling fills the distinguishability table of every pair of states of a DFA
+//
+//Missing transitions lead to an implicit dead state that accepts nothing
+func (dfa *DFA) TableFilling() *DistinguishabilityTable {
+	table := &DistinguishabilityTable{witnesses: make(map[[2]int][]string)}
+	table.States = append(table.States, dfa.States...)
+	sort.Ints(table.States)
+	symbols := dfa.symbols()
+	states := append([]int{deadState}, table.States...)
+	next := func(state int, symbol string) int {
+		if targetState, exists := dfa.Transitions[state][symbol]; exists && intArray.IndexOf(targetState, dfa.States) != -1 {
+			return targetState
+		}
+		return deadState
+	}
+	key := func(state int) string {
+		if state == deadState {
+			return ""
+		}
+		return dfa.acceptKey(state)
+	}
+	//round 0 separates states by acceptance, round n finds pairs told apart by words of length n
+	for i := 0; i < len(states); i++ {
+		for j := i + 1; j < len(states); j++ {
+			if key(states[i]) != key(states[j]) {
+				table.witnesses[pairKey(states[i], states[j])] = []string{}
+			}
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		found := make(map[[2]int][]string)
+		for i := 0; i < len(states); i++ {
+			for j := i + 1; j < len(states); j++ {
+				pair := pairKey(states[i], states[j])
+				if _, exists := table.witnesses[pair]; exists {
+					continue
+				}
+				for _, symbol := range symbols {
+					first, second := next(states[i], symbol), next(states[j], symbol)
+					if first == second {
+						continue
+					}
+					if witness, exists := table.witnesses[pairKey(first, second)]; exists {
+						found[pair] = append([]string{symbol}, witness...)
+						break
+					}
+				}
+			}
+		}
+		for pair, witness := range found {
+			table.witnesses[pair] = witness
+			changed = true
+		}
+	}
+	return table
+}
+
+//symbols of a DFA in sorted order, including any used by transitions but missing from Alphabet
+func (dfa *DFA) symbols() []string {
+	symbols := append([]string(nil), dfa.Alphabet...)
+	for _, transitions := range dfa.Transitions {
+		for symbol := range transitions {
+			if stringArray.IndexOf(symbol, symbols) == -1 {
+				symbols = append(symbols, symbol)
+			}
+		}
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+//Distinguish returns a shortest word telling the states p and q apart
+//
+//The word is accepted from exactly one of them or from both with different
+//accept labels, see DistinguishabilityTable. The second result is false when p and q are equivalent or not states of the table
+func (table *DistinguishabilityTable) Distinguish(p, q int) ([]string, bool) {
+	if p == q || intArray.IndexOf(p, table.States) == -1 || intArray.IndexOf(q, table.States) == -1 {
+		return nil, false
+	}
+	witness, exists := table.witnesses[pairKey(p, q)]
+	if !exists {
+		return nil, false
+	}
+	return append([]string{}, witness...), true
+}
+
+//Equivalent reports whether the states p and q accept the same words with the same accept labels
+func (table *DistinguishabilityTable) Equivalent(p, q int) bool {
+	if p == q {
+		return true
+	}
+	_, distinguishable := table.Distinguish(p, q)
+	return !distinguishable && intArray.IndexOf(p, table.States) != -1 && intArray.IndexOf(q, table.States) != -1
+}
+
+//WriteTable writes the lower triangle of the table, each cell holding the shortest
+//distinguishing word, ε for the empty word, or = for equivalent states
+func (table *DistinguishabilityTable) WriteTable(w io.Writer) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i := 1; i < len(table.States); i++ {
+		fmt.Fprintf(writer, "%d", table.States[i])
+		for j := 0; j < i; j++ {
+			witness, distinguishable := table.Distinguish(table.States[i], table.States[j])
+			switch {
+			case !distinguishable:
+				fmt.Fprintf(writer, "\t=")
+			case len(witness) == 0:
+				fmt.Fprintf(writer, "\tε")
+			default:
+				fmt.Fprintf(writer, "\t%s", strings.Join(witness, ""))
+			}
+		}
+		fmt.Fprintf(writer, "\n")
+	}
+	for j := 0; j < len(table.States)-1; j++ {
+		fmt.Fprintf(writer, "\t%d", table.States[j])
+	}
+	fmt.Fprintf(writer, "\n")
+	return writer.Flush()
+}
+
+//MinimizeTableFilling minimizes a DFA by merging the states the table-filling algorithm finds equivalent
+//
+//Like Minimize it drops unreachable states and states that can never accept
+func (dfa *DFA) MinimizeTableFilling() {
+	table := dfa.TableFilling()
+	minDFA := New()
+	minDFA.Alphabet = dfa.Alphabet
+	if len(dfa.StartStates) == 0 {
+		*dfa = *minDFA
+		return
+	}
+	symbols := dfa.symbols()
+	//classes are numbered in breadth first order from the start state
+	representatives := make([]int, 0)
+	classOf := func(state int) int {
+		for class, representative := range representatives {
+			if table.Equivalent(state, representative) {
+				return class
+			}
+		}
+		return -1
+	}
+	isDead := func(state int) bool {
+		_, distinguishable := table.witnesses[pairKey(state, deadState)]
+		return !distinguishable
+	}
+	queue := []int{dfa.StartStates[0]}
+	representatives = append(representatives, dfa.StartStates[0])
+	minDFA.AddState(true, intArray.IndexOf(dfa.StartStates[0], dfa.AcceptStates) != -1)
+	for len(queue) != 0 {
+		state := queue[0]
+		queue = queue[1:]
+		class := classOf(state)
+		for _, symbol := range symbols {
+			targetState, exists := dfa.Transitions[state][symbol]
+			if !exists || intArray.IndexOf(targetState, dfa.States) == -1 || isDead(targetState) {
+				continue
+			}
+			targetClass := classOf(targetState)
+			if targetClass == -1 {
+				representatives = append(representatives, targetState)
+				targetClass = minDFA.AddState(false, intArray.IndexOf(targetState, dfa.AcceptStates) != -1)
+				queue = append(queue, targetState)
+			}
+			minDFA.AddTransition(class, symbol, targetClass)
+		}
+	}
+	for class, representative := range representatives {
+		if labels, exists := dfa.AcceptLabels[representative]; exists {
+			minDFA.AcceptLabels[class] = append([]int(nil), labels...)
+		}
+	}
+	*dfa = *minDFA
+}
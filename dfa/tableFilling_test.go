@@ -0,0 +1,73 @@
+package dfa
+
+import (
+	"reflect"
+	"testing"
+)
+
+//labeledDFA has two accept states that differ only in their labels, and two that do not differ at all
+func labeledDFA() *DFA {
+	labeled := New()
+	labeled.Alphabet = []string{"a", "b"}
+	start := labeled.AddState(true, false)
+	first := labeled.AddState(false, true)
+	second := labeled.AddState(false, true)
+	third := labeled.AddState(false, true)
+	fourth := labeled.AddState(false, true)
+	labeled.AddTransition(start, "a", first)
+	labeled.AddTransition(start, "b", second)
+	labeled.AddTransition(first, "a", third)
+	labeled.AddTransition(second, "a", fourth)
+	labeled.AcceptLabels[first] = []int{0}
+	labeled.AcceptLabels[second] = []int{1}
+	labeled.AcceptLabels[third] = []int{0}
+	labeled.AcceptLabels[fourth] = []int{0}
+	return labeled
+}
+
+func TestDistinguish(t *testing.T) {
+	labeled := labeledDFA()
+	table := labeled.TableFilling()
+	for _, current := range []struct {
+		p, q     int
+		expected []string
+		ok       bool
+	}{
+		//both accept ε, only with different labels
+		{1, 2, []string{}, true},
+		{2, 4, []string{}, true},
+		{0, 1, []string{}, true},
+		//only 1 accepts a
+		{1, 3, []string{"a"}, true},
+		{3, 4, nil, false},
+		{1, 1, nil, false},
+		{1, 7, nil, false},
+	} {
+		witness, ok := table.Distinguish(current.p, current.q)
+		if ok != current.ok || !reflect.DeepEqual(witness, current.expected) {
+			t.Errorf("%d, %d: expected %v, %t, got %v, %t", current.p, current.q, current.expected, current.ok, witness, ok)
+		}
+	}
+	//every witness leads the two states to states differing in acceptance or labels
+	follow := func(state int, witness []string) string {
+		for _, symbol := range witness {
+			targetState, exists := labeled.Transitions[state][symbol]
+			if !exists {
+				return ""
+			}
+			state = targetState
+		}
+		return labeled.acceptKey(state)
+	}
+	for _, p := range labeled.States {
+		for _, q := range labeled.States {
+			witness, ok := table.Distinguish(p, q)
+			if ok == table.Equivalent(p, q) {
+				t.Errorf("%d, %d: Distinguish and Equivalent disagree", p, q)
+			}
+			if ok && follow(p, witness) == follow(q, witness) {
+				t.Errorf("%d, %d: %v does not tell them apart", p, q, witness)
+			}
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package dfa
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+	"github.com/ChristopherCamara/finiteAutomata/nfa"
+)
+
+//SubsetStep is one move of the subset construction
+type SubsetStep struct {
+	//From is the DFA state whose subset was processed
+	From   int
+	Symbol string
+	//Move holds the NFA states reached from the subset on Symbol
+	Move []int
+	//Closure is the epsilon closure of Move, the subset of the target DFA state
+	Closure []int
+	//To is the target DFA state, -1 when Closure is empty and there is no transition
+	To int
+	//New is set when Closure was first discovered by this step
+	New bool
+}
+
+//SubsetTrace records how FromNFAWithTrace built a DFA
+type SubsetTrace struct {
+	//Subsets holds the NFA states making up every DFA state, indexed by DFA state
+	Subsets [][]int
+	Steps   []SubsetStep
+	//Alphabet is sorted, the order in which the steps of every DFA state follow the symbols
+	Alphabet     []string
+	AcceptStates []int
+}
+
+//FromNFAWithTrace create a DFA from a NFA like FromNFA and return every step it took
+func FromNFAWithTrace(NFA *nfa.NFA) (*DFA, *SubsetTrace) {
+	trace := new(SubsetTrace)
+	dfa, _ := fromNFA(context.Background(), NFA, Limits{}, trace)
+	return dfa, trace
+}
+
+//WriteTable writes the trace as the familiar subset construction table
+//
+//Each row is a DFA state in the order it was processed with its subset of NFA
+//states, "->" marks the start state and "*" accept states. Each symbol has two
+//columns, the NFA states the move reaches and their epsilon closure with the
+//DFA state it became
+func (trace *SubsetTrace) WriteTable(w io.Writer) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "\tDFA state\tNFA states")
+	for _, symbol := range trace.Alphabet {
+		fmt.Fprintf(writer, "\tMove(%s)\tClosure(%s)", symbol, symbol)
+	}
+	fmt.Fprintf(writer, "\n")
+	for index, step := range trace.Steps {
+		if index == 0 || trace.Steps[index-1].From != step.From {
+			marker := ""
+			if step.From == 0 {
+				marker = "->"
+			}
+			if intArray.IndexOf(step.From, trace.AcceptStates) != -1 {
+				marker += "*"
+			}
+			fmt.Fprintf(writer, "%s\t%d\t%s", marker, step.From, formatSubset(trace.Subsets[step.From]))
+		}
+		if step.To == -1 {
+			fmt.Fprintf(writer, "\t∅\t∅")
+		} else {
+			fmt.Fprintf(writer, "\t%s\t%s = %d", formatSubset(step.Move), formatSubset(step.Closure), step.To)
+		}
+		if index == len(trace.Steps)-1 || trace.Steps[index+1].From != step.From {
+			fmt.Fprintf(writer, "\n")
+		}
+	}
+	if len(trace.Steps) == 0 && len(trace.Subsets) != 0 {
+		marker := "->"
+		if intArray.IndexOf(0, trace.AcceptStates) != -1 {
+			marker += "*"
+		}
+		fmt.Fprintf(writer, "%s\t0\t%s\n", marker, formatSubset(trace.Subsets[0]))
+	}
+	return writer.Flush()
+}
+
+func formatSubset(states []int) string {
+	parts := make([]string, 0, len(states))
+	for _, state := range states {
+		parts = append(parts, strconv.Itoa(state))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+//MinimizationSplit records a state moved out of its partition during a refinement round
+type MinimizationSplit struct {
+	//State was split off partition From into the new partition To
+	State int
+	From  int
+	To    int
+	//Witness stays in From, on Symbol exactly one of State and Witness moves into Partition
+	Witness   int
+	Symbol    string
+	Partition int
+}
+
+//MinimizationRound is one pass of partition refinement
+type MinimizationRound struct {
+	//Partitions at the start of the round, split partition indexes refer to these
+	Partitions [][]int
+	Splits     []MinimizationSplit
+}
+
+//MinimizationTrace records how MinimizeWithTrace built a minimal DFA
+type MinimizationTrace struct {
+	Rounds []MinimizationRound
+	//Partitions are the final equivalence classes without the states that can never accept, partition i became state i
+	Partitions [][]int
+	//StateMapping maps every old state of Partitions to its new state
+	StateMapping map[int]int
+	//SinkState was added to complete the transitions, -1 if none was needed
+	SinkState int
+}
+
+//MinimizeWithTrace returns the minimal DFA like Minimize together with every refinement round
+//
+//The DFA itself is left untouched
+func (dfa *DFA) MinimizeWithTrace() (*DFA, *MinimizationTrace) {
+	trace := new(MinimizationTrace)
+	minDFA := dfa.clone()
+	minDFA.minimize(context.Background(), trace)
+	return minDFA, trace
+}
+
+//WriteTable writes the equivalence classes of every round and the splits that refined them
+func (trace *MinimizationTrace) WriteTable(w io.Writer) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if trace.SinkState != -1 {
+		fmt.Fprintf(writer, "sink state %d completes the transitions\n", trace.SinkState)
+	}
+	fmt.Fprintf(writer, "round\tequivalence classes\tsplits\n")
+	for index, round := range trace.Rounds {
+		classes := make([]string, 0, len(round.Partitions))
+		for _, partition := range round.Partitions {
+			classes = append(classes, formatSubset(partition))
+		}
+		splits := make([]string, 0, len(round.Splits))
+		for _, split := range round.Splits {
+			splits = append(splits, fmt.Sprintf("%d from %d on %s into %s", split.State, split.Witness, split.Symbol, formatSubset(round.Partitions[split.Partition])))
+		}
+		if len(splits) == 0 {
+			splits = append(splits, "stable")
+		}
+		fmt.Fprintf(writer, "%d\t%s\t%s\n", index, strings.Join(classes, " "), strings.Join(splits, "; "))
+	}
+	fmt.Fprintf(writer, "\nold state\tnew state\n")
+	oldStates := make([]int, 0, len(trace.StateMapping))
+	for state := range trace.StateMapping {
+		oldStates = append(oldStates, state)
+	}
+	sort.Ints(oldStates)
+	for _, state := range oldStates {
+		fmt.Fprintf(writer, "%d\t%d\n", state, trace.StateMapping[state])
+	}
+	return writer.Flush()
+}
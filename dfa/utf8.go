@@ -0,0 +1,124 @@
+package dfa
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+	"github.com/ChristopherCamara/finiteAutomata/internal/stringArray"
+)
+
+//CompileUTF8 turns a DFA over single rune symbols into a DFA over bytes
+//
+//Every rune is replaced by its UTF-8 encoding, the encodings leaving a state
+//share their common prefixes so the result stays deterministic. Byte symbols
+//are one byte strings, so the result matches []byte input with a ByteMatcher
+//or a Bytes stream without decoding. Minimize the result to also share
+//common suffixes
+func (dfa *DFA) CompileUTF8() (*DFA, error) {
+	byteDFA := New()
+	stateMappings := make(map[int]int)
+	for _, state := range dfa.States {
+		stateMappings[state] = byteDFA.AddState(intArray.IndexOf(state, dfa.StartStates) != -1, intArray.IndexOf(state, dfa.AcceptStates) != -1)
+		if labels, exists := dfa.AcceptLabels[state]; exists {
+			byteDFA.AcceptLabels[stateMappings[state]] = append([]int(nil), labels...)
+		}
+	}
+	for _, state := range dfa.States {
+		for _, symbol := range dfa.sortedSymbols(state) {
+			current, size := utf8.DecodeRuneInString(symbol)
+			if len(symbol) == 0 || size != len(symbol) || (current == utf8.RuneError && size == 1) {
+				return nil, fmt.Errorf("symbol %q of state %d is not a single rune", symbol, state)
+			}
+			encoded := []byte(symbol)
+			//walk the trie of the encodings already leaving state, adding what is missing
+			currentState := stateMappings[state]
+			for _, b := range encoded[:len(encoded)-1] {
+				byteSymbol := string([]byte{b})
+				nextState, exists := byteDFA.Transitions[currentState][byteSymbol]
+				if !exists {
+					nextState = byteDFA.AddState(false, false)
+					byteDFA.addSymbol(byteSymbol)
+					byteDFA.AddTransition(currentState, byteSymbol, nextState)
+				}
+				currentState = nextState
+			}
+			byteSymbol := string(encoded[len(encoded)-1:])
+			byteDFA.addSymbol(byteSymbol)
+			byteDFA.AddTransition(currentState, byteSymbol, stateMappings[dfa.Transitions[state][symbol]])
+		}
+	}
+	sort.Strings(byteDFA.Alphabet)
+	return byteDFA, nil
+}
+
+//addSymbol to the Alphabet of a DFA unless it is already there
+func (dfa *DFA) addSymbol(symbol string) {
+	if stringArray.IndexOf(symbol, dfa.Alphabet) == -1 {
+		dfa.Alphabet = append(dfa.Alphabet, symbol)
+	}
+}
+
+//ByteMatcher runs a DFA over []byte input, one byte per symbol
+//
+//Transitions are kept in a table of 256 entries per state so every byte costs
+//a single index instead of a map lookup. Symbols longer than one byte can
+//never match and are left out. Build it once with NewByteMatcher and reuse it
+type ByteMatcher struct {
+	//transitions[state][b] is the state reached on byte b, -1 if there is none
+	transitions [][256]int
+	accepting   []bool
+	start       int
+}
+
+//NewByteMatcher returns ready to use *ByteMatcher for a DFA, usually one made by CompileUTF8
+func NewByteMatcher(dfa *DFA) *ByteMatcher {
+	matcher := &ByteMatcher{
+		transitions: make([][256]int, len(dfa.States)),
+		accepting:   make([]bool, len(dfa.States)),
+		start:       -1,
+	}
+	//states are renumbered densely in the order of dfa.States
+	indexes := make(map[int]int, len(dfa.States))
+	for index, state := range dfa.States {
+		indexes[state] = index
+	}
+	for index, state := range dfa.States {
+		for b := range matcher.transitions[index] {
+			matcher.transitions[index][b] = -1
+		}
+		for symbol, targetState := range dfa.Transitions[state] {
+			if targetIndex, exists := indexes[targetState]; exists && len(symbol) == 1 {
+				matcher.transitions[index][symbol[0]] = targetIndex
+			}
+		}
+		matcher.accepting[index] = intArray.IndexOf(state, dfa.AcceptStates) != -1
+	}
+	if len(dfa.StartStates) != 0 {
+		matcher.start = indexes[dfa.StartStates[0]]
+	}
+	return matcher
+}
+
+//Accepts reports whether input is in the language of the DFA
+func (matcher *ByteMatcher) Accepts(input []byte) bool {
+	if matcher.start == -1 {
+		return false
+	}
+	state := matcher.start
+	for _, current := range input {
+		state = matcher.transitions[state][current]
+		if state == -1 {
+			return false
+		}
+	}
+	return matcher.accepting[state]
+}
+
+//AcceptsBytes reports whether input, read one byte per symbol, is in the language of a DFA
+//
+//It builds a ByteMatcher on every call, use NewByteMatcher to match more than one input
+func (dfa *DFA) AcceptsBytes(input []byte) bool {
+	return NewByteMatcher(dfa).Accepts(input)
+}
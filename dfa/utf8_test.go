@@ -0,0 +1,92 @@
+package dfa
+
+import (
+	"testing"
+)
+
+//runeDFA accepts "é" or "è" followed by any number of "€" and an optional final "😀"
+func runeDFA() *DFA {
+	runes := New()
+	runes.Alphabet = []string{"é", "è", "€", "😀"}
+	startState := runes.AddState(true, false)
+	loopState := runes.AddState(false, true)
+	endState := runes.AddState(false, true)
+	runes.AddTransition(startState, "é", loopState)
+	runes.AddTransition(startState, "è", loopState)
+	runes.AddTransition(loopState, "€", loopState)
+	runes.AddTransition(loopState, "😀", endState)
+	return runes
+}
+
+var utf8Inputs = map[string]bool{
+	"":                 false,
+	"é":                true,
+	"è€€":              true,
+	"é€😀":              true,
+	"é😀€":              false,
+	"€":                false,
+	"e":                false,
+	"\xc3":             false,
+	"\xc3\xa9\xe2\x82": false,
+	"\xa9":             false,
+	"é\xff":            false,
+	"\xe9":             false,
+}
+
+func TestCompileUTF8(t *testing.T) {
+	runes := runeDFA()
+	compiled, err := runes.CompileUTF8()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, symbol := range compiled.Alphabet {
+		if len(symbol) != 1 {
+			t.Errorf("symbol %q is not a single byte", symbol)
+		}
+	}
+	//"é" and "è" share their leading byte 0xc3, so the start state has a single transition
+	if len(compiled.Transitions[compiled.StartStates[0]]) != 1 {
+		t.Errorf("expected the encodings to share their prefix, got %v", compiled.Transitions[compiled.StartStates[0]])
+	}
+	minimized, err := runes.CompileUTF8()
+	if err != nil {
+		t.Fatal(err)
+	}
+	minimized.Minimize()
+	matcher := NewByteMatcher(compiled)
+	for input, expected := range utf8Inputs {
+		if runes.Accepts(input) != expected {
+			t.Errorf("rune DFA on %q: expected %t", input, expected)
+		}
+		if matcher.Accepts([]byte(input)) != expected {
+			t.Errorf("ByteMatcher on %q: expected %t", input, expected)
+		}
+		if minimized.AcceptsBytes([]byte(input)) != expected {
+			t.Errorf("minimized byte DFA on %q: expected %t", input, expected)
+		}
+	}
+}
+
+func TestCompileUTF8InvalidSymbols(t *testing.T) {
+	for _, symbol := range []string{"", "ab", "\xff", "\xc3", "é€"} {
+		runes := New()
+		state := runes.AddState(true, true)
+		runes.Alphabet = []string{symbol}
+		runes.AddTransition(state, symbol, state)
+		if _, err := runes.CompileUTF8(); err == nil {
+			t.Errorf("expected an error for symbol %q", symbol)
+		}
+	}
+}
+
+func TestByteMatcherEmpty(t *testing.T) {
+	if NewByteMatcher(New()).Accepts(nil) {
+		t.Error("a DFA without a start state accepts the empty input")
+	}
+	empty := New()
+	empty.AddState(true, true)
+	matcher := NewByteMatcher(empty)
+	if !matcher.Accepts(nil) || matcher.Accepts([]byte("a")) {
+		t.Error("expected only the empty input to be accepted")
+	}
+}
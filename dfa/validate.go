@@ -0,0 +1,105 @@
+package dfa
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+	"github.com/ChristopherCamara/finiteAutomata/internal/stringArray"
+)
+
+//Problem found by Validate, Location names the offending field like Transitions[0]["a"]
+type Problem struct {
+	Location string
+	Message  string
+}
+
+//ValidationError lists every Problem found by Validate
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (err *ValidationError) Error() string {
+	messages := make([]string, 0, len(err.Problems))
+	for _, problem := range err.Problems {
+		messages = append(messages, problem.Location+": "+problem.Message)
+	}
+	return fmt.Sprintf("invalid DFA, %d problem(s): %s", len(err.Problems), strings.Join(messages, "; "))
+}
+
+func (err *ValidationError) add(location, format string, args ...interface{}) {
+	err.Problems = append(err.Problems, Problem{Location: location, Message: fmt.Sprintf(format, args...)})
+}
+
+//Validate reports every inconsistency between the exported fields of a DFA as a *ValidationError
+func (dfa *DFA) Validate() error {
+	err := new(ValidationError)
+	for index, symbol := range dfa.Alphabet {
+		if stringArray.IndexOf(symbol, dfa.Alphabet) != index {
+			err.add(fmt.Sprintf("Alphabet[%d]", index), "duplicate symbol %q", symbol)
+		}
+	}
+	for index, state := range dfa.States {
+		if intArray.IndexOf(state, dfa.States) != index {
+			err.add(fmt.Sprintf("States[%d]", index), "duplicate state %d", state)
+		}
+		if _, exists := dfa.Transitions[state]; !exists {
+			err.add(fmt.Sprintf("Transitions[%d]", state), "missing transition map for state %d", state)
+		}
+	}
+	if len(dfa.StartStates) == 0 {
+		err.add("StartStates", "no start state")
+	} else if len(dfa.StartStates) > 1 {
+		err.add("StartStates", "%d start states, a DFA has exactly one", len(dfa.StartStates))
+	}
+	for index, state := range dfa.StartStates {
+		if intArray.IndexOf(state, dfa.States) == -1 {
+			err.add(fmt.Sprintf("StartStates[%d]", index), "unknown state %d", state)
+		}
+	}
+	for index, state := range dfa.AcceptStates {
+		if intArray.IndexOf(state, dfa.States) == -1 {
+			err.add(fmt.Sprintf("AcceptStates[%d]", index), "unknown state %d", state)
+		}
+	}
+	sources := make([]int, 0, len(dfa.Transitions))
+	for state := range dfa.Transitions {
+		sources = append(sources, state)
+	}
+	sort.Ints(sources)
+	for _, state := range sources {
+		location := fmt.Sprintf("Transitions[%d]", state)
+		if intArray.IndexOf(state, dfa.States) == -1 {
+			err.add(location, "transitions from unknown state %d", state)
+		}
+		symbols := make([]string, 0, len(dfa.Transitions[state]))
+		for symbol := range dfa.Transitions[state] {
+			symbols = append(symbols, symbol)
+		}
+		sort.Strings(symbols)
+		for _, symbol := range symbols {
+			symbolLocation := fmt.Sprintf("%s[%q]", location, symbol)
+			if stringArray.IndexOf(symbol, dfa.Alphabet) == -1 {
+				err.add(symbolLocation, "symbol %q not in alphabet", symbol)
+			}
+			if targetState := dfa.Transitions[state][symbol]; intArray.IndexOf(targetState, dfa.States) == -1 {
+				err.add(symbolLocation, "transition to unknown state %d", targetState)
+			}
+		}
+	}
+	labeledStates := make([]int, 0, len(dfa.AcceptLabels))
+	for state := range dfa.AcceptLabels {
+		labeledStates = append(labeledStates, state)
+	}
+	sort.Ints(labeledStates)
+	for _, state := range labeledStates {
+		if intArray.IndexOf(state, dfa.AcceptStates) == -1 {
+			err.add(fmt.Sprintf("AcceptLabels[%d]", state), "labels on state %d which is not an accept state", state)
+		}
+	}
+	if len(err.Problems) != 0 {
+		return err
+	}
+	return nil
+}
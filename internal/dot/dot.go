@@ -0,0 +1,116 @@
+package dot
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//Graph of an automaton ready to be written as DOT text
+type Graph struct {
+	RankDir       string
+	Nodes         []Node
+	Edges         []Edge
+	SeparateEdges bool
+}
+
+//Node is one state, drawn as a circle or a double circle when it accepts
+type Node struct {
+	ID        int
+	Label     string
+	Start     bool
+	Accept    bool
+	Highlight bool
+}
+
+//Edge is one transition
+type Edge struct {
+	From  int
+	To    int
+	Label string
+}
+
+type edge struct {
+	from  int
+	to    int
+	label string
+}
+
+//Write the graph as a DOT digraph
+//
+//Start states get an arrow from an invisible node and, unless SeparateEdges
+//is set, edges between the same two states share one comma joined label
+func (g *Graph) Write(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	rankDir := g.RankDir
+	if rankDir == "" {
+		rankDir = "LR"
+	}
+	fmt.Fprintf(writer, "digraph {\n")
+	fmt.Fprintf(writer, "\trankdir=%s;\n", rankDir)
+	fmt.Fprintf(writer, "\tnode [shape=circle];\n")
+	for _, node := range g.Nodes {
+		attributes := make([]string, 0)
+		if node.Label != "" {
+			attributes = append(attributes, "label="+Quote(node.Label))
+		}
+		if node.Accept {
+			attributes = append(attributes, "shape=doublecircle")
+		}
+		if node.Highlight {
+			attributes = append(attributes, "style=filled", "fillcolor=lightblue")
+		}
+		if len(attributes) == 0 {
+			fmt.Fprintf(writer, "\t%d;\n", node.ID)
+		} else {
+			fmt.Fprintf(writer, "\t%d [%s];\n", node.ID, strings.Join(attributes, ", "))
+		}
+	}
+	for _, node := range g.Nodes {
+		if node.Start {
+			fmt.Fprintf(writer, "\t__start%d [shape=none, label=\"\"];\n", node.ID)
+			fmt.Fprintf(writer, "\t__start%d -> %d;\n", node.ID, node.ID)
+		}
+	}
+	edges := make([]*edge, 0, len(g.Edges))
+	edgeMappings := make(map[int]map[int]*edge)
+	for _, current := range g.Edges {
+		if !g.SeparateEdges {
+			if currentEdge, exists := edgeMappings[current.From][current.To]; exists {
+				currentEdge.label = currentEdge.label + "," + current.Label
+				continue
+			}
+		}
+		newEdge := &edge{from: current.From, to: current.To, label: current.Label}
+		if edgeMappings[current.From] == nil {
+			edgeMappings[current.From] = make(map[int]*edge)
+		}
+		edgeMappings[current.From][current.To] = newEdge
+		edges = append(edges, newEdge)
+	}
+	for _, current := range edges {
+		fmt.Fprintf(writer, "\t%d -> %d [label=%s];\n", current.from, current.to, Quote(current.label))
+	}
+	fmt.Fprintf(writer, "}\n")
+	return writer.Flush()
+}
+
+//Quote a DOT string
+func Quote(text string) string {
+	var quoted strings.Builder
+	quoted.WriteByte('"')
+	for _, current := range text {
+		switch current {
+		case '"', '\\':
+			quoted.WriteByte('\\')
+			quoted.WriteRune(current)
+		case '\n':
+			quoted.WriteString("\\n")
+		default:
+			quoted.WriteRune(current)
+		}
+	}
+	quoted.WriteByte('"')
+	return quoted.String()
+}
@@ -0,0 +1,476 @@
+package dot
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ChristopherCamara/finiteAutomata/internal/stringArray"
+)
+
+//Automaton read back from a DOT digraph, states are named by their DOT node IDs
+type Automaton struct {
+	States       []string
+	StartStates  []string
+	AcceptStates []string
+	Transitions  []Transition
+}
+
+//Transition read from one edge label, Epsilon is set for ε or λ labels
+type Transition struct {
+	From    string
+	To      string
+	Symbol  string
+	Epsilon bool
+}
+
+//ReadAutomaton parses a DOT digraph drawn the way WriteDOT and SaveGraphviz draw automata
+//
+//Nodes with shape doublecircle accept, edges leaving an invisible node (shape
+//none, plaintext or point, or style invis) mark start states and edge labels
+//are split on commas into one transition per symbol
+func ReadAutomaton(r io.Reader) (*Automaton, error) {
+	text, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{lexer: &lexer{text: string(text), line: 1}, nodeAttributes: make(map[string]map[string]string)}
+	if err := p.graph(); err != nil {
+		return nil, err
+	}
+	automaton := new(Automaton)
+	invisible := make([]string, 0)
+	for _, name := range p.nodes {
+		attributes := p.nodeAttributes[name]
+		switch {
+		case isInvisible(name, attributes):
+			invisible = append(invisible, name)
+		default:
+			automaton.States = append(automaton.States, name)
+			if strings.EqualFold(attributes["shape"], "doublecircle") {
+				automaton.AcceptStates = append(automaton.AcceptStates, name)
+			}
+		}
+	}
+	for _, current := range p.edges {
+		if stringArray.IndexOf(current.to, invisible) != -1 {
+			return nil, fmt.Errorf("edge %s -> %s points at invisible node %s", current.from, current.to, current.to)
+		}
+		if stringArray.IndexOf(current.from, invisible) != -1 {
+			if stringArray.IndexOf(current.to, automaton.StartStates) == -1 {
+				automaton.StartStates = append(automaton.StartStates, current.to)
+			}
+			continue
+		}
+		for _, symbol := range strings.Split(current.attributes["label"], ",") {
+			symbol = strings.TrimSpace(symbol)
+			switch symbol {
+			case "":
+				return nil, fmt.Errorf("edge %s -> %s has an empty label", current.from, current.to)
+			case "ε", "λ":
+				automaton.Transitions = append(automaton.Transitions, Transition{From: current.from, To: current.to, Epsilon: true})
+			default:
+				automaton.Transitions = append(automaton.Transitions, Transition{From: current.from, To: current.to, Symbol: symbol})
+			}
+		}
+	}
+	return automaton, nil
+}
+
+func isInvisible(name string, attributes map[string]string) bool {
+	if name == "" {
+		return true
+	}
+	switch strings.ToLower(attributes["shape"]) {
+	case "none", "plaintext", "plain", "point":
+		return true
+	}
+	return strings.Contains(strings.ToLower(attributes["style"]), "invis")
+}
+
+type token struct {
+	text   string
+	quoted bool
+	line   int
+}
+
+type lexer struct {
+	text     string
+	position int
+	line     int
+}
+
+//next token, an empty unquoted token at the end of input
+func (l *lexer) next() (token, error) {
+	l.skip()
+	if l.position >= len(l.text) {
+		return token{line: l.line}, nil
+	}
+	start := l.position
+	current := l.text[l.position]
+	switch {
+	case current == '"':
+		return l.quoted()
+	case current == '<':
+		return l.html()
+	case current == '-' && l.position+1 < len(l.text) && (l.text[l.position+1] == '>' || l.text[l.position+1] == '-'):
+		l.position += 2
+		return token{text: l.text[start:l.position], line: l.line}, nil
+	case strings.IndexByte("{}[]=;,:", current) != -1:
+		l.position++
+		return token{text: l.text[start:l.position], line: l.line}, nil
+	}
+	for l.position < len(l.text) {
+		symbol, size := utf8.DecodeRuneInString(l.text[l.position:])
+		isLeadingMinus := symbol == '-' && l.position == start
+		if !isLeadingMinus && symbol != '_' && symbol != '.' && symbol < utf8.RuneSelf && !unicode.IsLetter(symbol) && !unicode.IsDigit(symbol) {
+			break
+		}
+		l.position += size
+	}
+	if l.position == start {
+		return token{}, fmt.Errorf("line %d: unexpected character %q", l.line, current)
+	}
+	return token{text: l.text[start:l.position], line: l.line}, nil
+}
+
+//skip white space and comments
+func (l *lexer) skip() {
+	for l.position < len(l.text) {
+		rest := l.text[l.position:]
+		switch {
+		case rest[0] == '\n':
+			l.line++
+			l.position++
+		case rest[0] == ' ' || rest[0] == '\t' || rest[0] == '\r':
+			l.position++
+		case strings.HasPrefix(rest, "//") || (rest[0] == '#' && (l.position == 0 || l.text[l.position-1] == '\n')):
+			end := strings.IndexByte(rest, '\n')
+			if end == -1 {
+				end = len(rest)
+			}
+			l.position += end
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end == -1 {
+				end = len(rest)
+			} else {
+				end += 4
+			}
+			l.line += strings.Count(rest[:end], "\n")
+			l.position += end
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) quoted() (token, error) {
+	line := l.line
+	var text strings.Builder
+	l.position++
+	for l.position < len(l.text) {
+		current := l.text[l.position]
+		switch {
+		case current == '"':
+			l.position++
+			return token{text: text.String(), quoted: true, line: line}, nil
+		case current == '\\' && l.position+1 < len(l.text) && l.text[l.position+1] == '"':
+			text.WriteByte('"')
+			l.position += 2
+		case current == '\\' && l.position+1 < len(l.text) && l.text[l.position+1] == '\\':
+			text.WriteByte('\\')
+			l.position += 2
+		case current == '\\' && l.position+1 < len(l.text) && l.text[l.position+1] == '\n':
+			l.line++
+			l.position += 2
+		default:
+			if current == '\n' {
+				l.line++
+			}
+			text.WriteByte(current)
+			l.position++
+		}
+	}
+	return token{}, fmt.Errorf("line %d: unterminated string", line)
+}
+
+func (l *lexer) html() (token, error) {
+	line := l.line
+	depth := 0
+	start := l.position
+	for l.position < len(l.text) {
+		switch l.text[l.position] {
+		case '<':
+			depth++
+		case '>':
+			depth--
+		case '\n':
+			l.line++
+		}
+		l.position++
+		if depth == 0 {
+			return token{text: l.text[start+1 : l.position-1], quoted: true, line: line}, nil
+		}
+	}
+	return token{}, fmt.Errorf("line %d: unterminated HTML string", line)
+}
+
+type parsedEdge struct {
+	from       string
+	to         string
+	attributes map[string]string
+}
+
+type parser struct {
+	lexer          *lexer
+	peeked         *token
+	nodes          []string
+	nodeAttributes map[string]map[string]string
+	edges          []parsedEdge
+}
+
+func (p *parser) peek() (token, error) {
+	if p.peeked == nil {
+		current, err := p.lexer.next()
+		if err != nil {
+			return token{}, err
+		}
+		p.peeked = &current
+	}
+	return *p.peeked, nil
+}
+
+func (p *parser) next() (token, error) {
+	current, err := p.peek()
+	p.peeked = nil
+	return current, err
+}
+
+func (p *parser) eat(text string) error {
+	current, err := p.next()
+	if err != nil {
+		return err
+	}
+	if current.quoted || current.text != text {
+		return fmt.Errorf("line %d: expected %q, found %q", current.line, text, current.text)
+	}
+	return nil
+}
+
+func isKeyword(current token, keyword string) bool {
+	return !current.quoted && strings.EqualFold(current.text, keyword)
+}
+
+func isID(current token) bool {
+	return current.quoted || (current.text != "" && strings.IndexByte("{}[]=;,:", current.text[0]) == -1 && current.text != "->" && current.text != "--")
+}
+
+func (p *parser) graph() error {
+	current, err := p.next()
+	if err != nil {
+		return err
+	}
+	if isKeyword(current, "strict") {
+		if current, err = p.next(); err != nil {
+			return err
+		}
+	}
+	if !isKeyword(current, "digraph") {
+		return fmt.Errorf("line %d: expected digraph, found %q", current.line, current.text)
+	}
+	if current, err = p.peek(); err != nil {
+		return err
+	}
+	if isID(current) {
+		p.next()
+	}
+	if err := p.eat("{"); err != nil {
+		return err
+	}
+	return p.statements(make(map[string]string), make(map[string]string))
+}
+
+//statements up to and including the closing brace, defaults are the node and edge attributes in scope
+func (p *parser) statements(nodeDefaults, edgeDefaults map[string]string) error {
+	for {
+		current, err := p.next()
+		if err != nil {
+			return err
+		}
+		switch {
+		case !current.quoted && current.text == "}":
+			return nil
+		case !current.quoted && current.text == "":
+			return fmt.Errorf("line %d: unexpected end of input", current.line)
+		case !current.quoted && current.text == ";":
+			continue
+		case isKeyword(current, "graph"):
+			if _, err := p.attributes(nil); err != nil {
+				return err
+			}
+		case isKeyword(current, "node"):
+			if nodeDefaults, err = p.attributes(nodeDefaults); err != nil {
+				return err
+			}
+		case isKeyword(current, "edge"):
+			if edgeDefaults, err = p.attributes(edgeDefaults); err != nil {
+				return err
+			}
+		case isKeyword(current, "subgraph") || (!current.quoted && current.text == "{"):
+			if !current.quoted && current.text != "{" {
+				if current, err = p.next(); err != nil {
+					return err
+				}
+				if isID(current) {
+					if current, err = p.next(); err != nil {
+						return err
+					}
+				}
+				if current.quoted || current.text != "{" {
+					return fmt.Errorf("line %d: expected { after subgraph", current.line)
+				}
+			}
+			if err := p.statements(copyAttributes(nodeDefaults), copyAttributes(edgeDefaults)); err != nil {
+				return err
+			}
+		case isID(current):
+			if err := p.nodeOrEdge(current, nodeDefaults, edgeDefaults); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("line %d: unexpected %q", current.line, current.text)
+		}
+	}
+}
+
+func (p *parser) nodeOrEdge(first token, nodeDefaults, edgeDefaults map[string]string) error {
+	names := []string{first.text}
+	if err := p.port(); err != nil {
+		return err
+	}
+	next, err := p.peek()
+	if err != nil {
+		return err
+	}
+	if !next.quoted && next.text == "=" {
+		//graph attribute statement
+		p.next()
+		_, err := p.next()
+		return err
+	}
+	for !next.quoted && (next.text == "->" || next.text == "--") {
+		p.next()
+		current, err := p.next()
+		if err != nil {
+			return err
+		}
+		if !isID(current) {
+			return fmt.Errorf("line %d: expected node after edge operator, found %q", current.line, current.text)
+		}
+		names = append(names, current.text)
+		if err := p.port(); err != nil {
+			return err
+		}
+		if next, err = p.peek(); err != nil {
+			return err
+		}
+	}
+	if len(names) == 1 {
+		p.addNode(names[0], nodeDefaults)
+		attributes, err := p.attributes(p.nodeAttributes[names[0]])
+		if err != nil {
+			return err
+		}
+		p.nodeAttributes[names[0]] = attributes
+		return nil
+	}
+	attributes, err := p.attributes(edgeDefaults)
+	if err != nil {
+		return err
+	}
+	for index := 0; index < len(names)-1; index++ {
+		p.addNode(names[index], nodeDefaults)
+		p.addNode(names[index+1], nodeDefaults)
+		p.edges = append(p.edges, parsedEdge{from: names[index], to: names[index+1], attributes: attributes})
+	}
+	return nil
+}
+
+//port after a node ID is skipped
+func (p *parser) port() error {
+	for {
+		current, err := p.peek()
+		if err != nil || current.quoted || current.text != ":" {
+			return err
+		}
+		p.next()
+		if _, err := p.next(); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *parser) addNode(name string, nodeDefaults map[string]string) {
+	if _, exists := p.nodeAttributes[name]; exists {
+		return
+	}
+	p.nodes = append(p.nodes, name)
+	p.nodeAttributes[name] = copyAttributes(nodeDefaults)
+}
+
+//attributes of any number of [...] lists, added on top of a copy of base
+func (p *parser) attributes(base map[string]string) (map[string]string, error) {
+	attributes := copyAttributes(base)
+	for {
+		current, err := p.peek()
+		if err != nil {
+			return nil, err
+		}
+		if current.quoted || current.text != "[" {
+			return attributes, nil
+		}
+		p.next()
+		for {
+			key, err := p.next()
+			if err != nil {
+				return nil, err
+			}
+			if !key.quoted && key.text == "]" {
+				break
+			}
+			if !key.quoted && (key.text == "," || key.text == ";") {
+				continue
+			}
+			if !isID(key) {
+				return nil, fmt.Errorf("line %d: expected attribute name, found %q", key.line, key.text)
+			}
+			value := "true"
+			if next, err := p.peek(); err != nil {
+				return nil, err
+			} else if !next.quoted && next.text == "=" {
+				p.next()
+				valueToken, err := p.next()
+				if err != nil {
+					return nil, err
+				}
+				if !isID(valueToken) {
+					return nil, fmt.Errorf("line %d: expected attribute value, found %q", valueToken.line, valueToken.text)
+				}
+				value = valueToken.text
+			}
+			attributes[strings.ToLower(key.text)] = value
+		}
+	}
+}
+
+func copyAttributes(attributes map[string]string) map[string]string {
+	copied := make(map[string]string, len(attributes))
+	for key, value := range attributes {
+		copied[key] = value
+	}
+	return copied
+}
@@ -0,0 +1,65 @@
+package dot
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadAutomaton(t *testing.T) {
+	source := `/* drawn by hand */
+digraph "my graph" {
+	rankdir=LR;
+	node [shape = circle];
+	"" [shape=none];
+	start2 [shape=point]
+	"" -> "q 0";
+	start2 -> q1 // a second start state
+	"q 0" [shape=doublecircle][color=red]
+	"q 0" -> q1 [label="a, b"];
+	q1 -> "say \"hi\"" [label=ε]
+	node [shape=doublecircle]
+	q3
+	q1 -> q3 -> "q 0" [label=<c>]
+}`
+	automaton, err := ReadAutomaton(strings.NewReader(source))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &Automaton{
+		States:       []string{"q 0", "q1", `say "hi"`, "q3"},
+		StartStates:  []string{"q 0", "q1"},
+		AcceptStates: []string{"q 0", "q3"},
+		Transitions: []Transition{
+			{From: "q 0", To: "q1", Symbol: "a"},
+			{From: "q 0", To: "q1", Symbol: "b"},
+			{From: "q1", To: `say "hi"`, Epsilon: true},
+			{From: "q1", To: "q3", Symbol: "c"},
+			{From: "q3", To: "q 0", Symbol: "c"},
+		},
+	}
+	if !reflect.DeepEqual(automaton, expected) {
+		t.Errorf("expected %+v, got %+v", expected, automaton)
+	}
+}
+
+func TestReadAutomatonMalformed(t *testing.T) {
+	for source, expected := range map[string]string{
+		"graph { a -- b }":                             "expected digraph",
+		"digraph { a -> b":                             "unexpected end of input",
+		"digraph { a -> }":                             "expected node after edge operator",
+		"digraph {\n\n a -> b [label=\"a]\n}":          "line 3: unterminated string",
+		"digraph { a -> b [label=<a] }":                "unterminated HTML string",
+		"digraph { a -> b [=a] }":                      "expected attribute name",
+		"digraph { a -> b [label=] }":                  "expected attribute value",
+		"digraph { a -> b [label=a] @ }":               "unexpected character '@'",
+		"digraph { a -> b [label=\"\"] }":              "empty label",
+		"digraph { a -> b [label=\"a,\"] }":            "empty label",
+		"digraph { a [shape=none]; b -> a [label=x] }": "points at invisible node a",
+		"digraph { subgraph x y }":                     "expected { after subgraph",
+	} {
+		if _, err := ReadAutomaton(strings.NewReader(source)); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("%q: expected error containing %q, got %v", source, expected, err)
+		}
+	}
+}
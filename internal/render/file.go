@@ -0,0 +1,24 @@
+package render
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+//File writes the output of render to fileName once render succeeds
+//
+//The image is built in memory first so a failed render leaves no file behind
+//and does not touch an existing one, a failed write removes the partial file
+func File(fileName string, render func(w io.Writer) error) error {
+	var image bytes.Buffer
+	if err := render(&image); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(fileName, image.Bytes(), 0666); err != nil {
+		os.Remove(fileName)
+		return err
+	}
+	return nil
+}
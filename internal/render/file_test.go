@@ -0,0 +1,46 @@
+package render
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFile(t *testing.T) {
+	directory, err := ioutil.TempDir("", "render")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+	fileName := filepath.Join(directory, "graph.png")
+	failed := errors.New("render failed")
+	partial := func(w io.Writer) error {
+		w.Write([]byte("partial"))
+		return failed
+	}
+	if err := File(fileName, partial); err != failed {
+		t.Fatalf("expected %v, got %v", failed, err)
+	}
+	if _, err := os.Stat(fileName); !os.IsNotExist(err) {
+		t.Errorf("expected no file after a failed render, got %v", err)
+	}
+	if err := File(fileName, func(w io.Writer) error {
+		_, err := w.Write([]byte("image"))
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	//a failed render leaves an earlier image alone
+	if err := File(fileName, partial); err != failed {
+		t.Fatalf("expected %v, got %v", failed, err)
+	}
+	if content, err := ioutil.ReadFile(fileName); err != nil || string(content) != "image" {
+		t.Errorf("expected the earlier image, got %q, %v", content, err)
+	}
+	if err := File(filepath.Join(directory, "missing", "graph.png"), partial); err != failed {
+		t.Errorf("expected the render error first, got %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+//go:build cgo
+// +build cgo
+
+package render
+
+import (
+	"io"
+
+	"github.com/goccy/go-graphviz"
+)
+
+//DOT text to w as an image of the given format, laid out by the given Graphviz engine or dot when empty
+func DOT(dotText []byte, w io.Writer, format, layout string) (err error) {
+	if layout == "" {
+		layout = "dot"
+	}
+	graph, err := graphviz.ParseBytes(dotText)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := graph.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	g := graphviz.New()
+	defer func() {
+		if closeErr := g.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	g.SetLayout(graphviz.Layout(layout))
+	return g.Render(graph, graphviz.Format(format), w)
+}
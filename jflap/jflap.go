@@ -0,0 +1,270 @@
+package jflap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/ChristopherCamara/finiteAutomata/dfa"
+	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+	"github.com/ChristopherCamara/finiteAutomata/internal/stringArray"
+	"github.com/ChristopherCamara/finiteAutomata/nfa"
+)
+
+//State holds what a .jff file records about a state besides its transitions
+type State struct {
+	Name        string
+	X           float64
+	Y           float64
+	HasPosition bool
+}
+
+//Layout of the states of an automaton, keyed by state number
+type Layout map[int]State
+
+type jffStructure struct {
+	XMLName   xml.Name      `xml:"structure"`
+	Type      string        `xml:"type"`
+	Automaton *jffAutomaton `xml:"automaton"`
+	//JFLAP 6 files list states and transitions directly under structure
+	States      []jffState      `xml:"state"`
+	Transitions []jffTransition `xml:"transition"`
+}
+
+type jffAutomaton struct {
+	States      []jffState      `xml:"state"`
+	Transitions []jffTransition `xml:"transition"`
+}
+
+type jffState struct {
+	ID      string    `xml:"id,attr"`
+	Name    string    `xml:"name,attr,omitempty"`
+	X       *float64  `xml:"x"`
+	Y       *float64  `xml:"y"`
+	Initial *struct{} `xml:"initial"`
+	Final   *struct{} `xml:"final"`
+}
+
+type jffTransition struct {
+	From string  `xml:"from"`
+	To   string  `xml:"to"`
+	Read *string `xml:"read"`
+}
+
+func read(r io.Reader) (*jffStructure, error) {
+	structure := new(jffStructure)
+	if err := xml.NewDecoder(r).Decode(structure); err != nil {
+		return nil, err
+	}
+	if structure.Type != "fa" {
+		return nil, fmt.Errorf("unsupported JFLAP structure type %q, only fa is supported", structure.Type)
+	}
+	if structure.Automaton != nil {
+		structure.States = append(structure.States, structure.Automaton.States...)
+		structure.Transitions = append(structure.Transitions, structure.Automaton.Transitions...)
+	}
+	return structure, nil
+}
+
+//ReadNFA reads a JFLAP finite automaton, an empty <read/> becomes an epsilon transition
+//
+//States are numbered in file order, their names and coordinates are returned in the Layout
+func ReadNFA(r io.Reader) (*nfa.NFA, Layout, error) {
+	structure, err := read(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	newNFA := nfa.New()
+	layout := make(Layout)
+	stateMappings := make(map[string]int)
+	for _, state := range structure.States {
+		if _, exists := stateMappings[state.ID]; exists {
+			return nil, nil, fmt.Errorf("duplicate state id %q", state.ID)
+		}
+		newState := newNFA.AddState(state.Initial != nil, state.Final != nil)
+		stateMappings[state.ID] = newState
+		layout[newState] = layoutState(state)
+	}
+	for _, transition := range structure.Transitions {
+		sourceState, targetState, err := transitionStates(transition, stateMappings)
+		if err != nil {
+			return nil, nil, err
+		}
+		if transition.Read == nil || *transition.Read == "" {
+			newNFA.AddEpsilonTransition(sourceState, targetState)
+			continue
+		}
+		if stringArray.IndexOf(*transition.Read, newNFA.Alphabet) == -1 {
+			newNFA.Alphabet = append(newNFA.Alphabet, *transition.Read)
+		}
+		newNFA.AddTransition(sourceState, *transition.Read, targetState)
+	}
+	if err := newNFA.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return newNFA, layout, nil
+}
+
+//ReadDFA reads a JFLAP finite automaton that must be deterministic
+//
+//States are numbered in file order, their names and coordinates are returned in the Layout
+func ReadDFA(r io.Reader) (*dfa.DFA, Layout, error) {
+	structure, err := read(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	newDFA := dfa.New()
+	layout := make(Layout)
+	stateMappings := make(map[string]int)
+	for _, state := range structure.States {
+		if _, exists := stateMappings[state.ID]; exists {
+			return nil, nil, fmt.Errorf("duplicate state id %q", state.ID)
+		}
+		newState := newDFA.AddState(state.Initial != nil, state.Final != nil)
+		stateMappings[state.ID] = newState
+		layout[newState] = layoutState(state)
+	}
+	if len(newDFA.StartStates) > 1 {
+		return nil, nil, fmt.Errorf("%d initial states in a DFA", len(newDFA.StartStates))
+	}
+	for _, transition := range structure.Transitions {
+		sourceState, targetState, err := transitionStates(transition, stateMappings)
+		if err != nil {
+			return nil, nil, err
+		}
+		if transition.Read == nil || *transition.Read == "" {
+			return nil, nil, fmt.Errorf("λ transition from state %s to %s in a DFA", transition.From, transition.To)
+		}
+		if _, exists := newDFA.Transitions[sourceState][*transition.Read]; exists {
+			return nil, nil, fmt.Errorf("state %s has more than one transition reading %q", transition.From, *transition.Read)
+		}
+		if stringArray.IndexOf(*transition.Read, newDFA.Alphabet) == -1 {
+			newDFA.Alphabet = append(newDFA.Alphabet, *transition.Read)
+		}
+		newDFA.AddTransition(sourceState, *transition.Read, targetState)
+	}
+	if err := newDFA.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return newDFA, layout, nil
+}
+
+func layoutState(state jffState) State {
+	current := State{Name: state.Name}
+	if state.X != nil && state.Y != nil {
+		current.X = *state.X
+		current.Y = *state.Y
+		current.HasPosition = true
+	}
+	return current
+}
+
+func transitionStates(transition jffTransition, stateMappings map[string]int) (int, int, error) {
+	sourceState, exists := stateMappings[transition.From]
+	if !exists {
+		return 0, 0, fmt.Errorf("transition from unknown state id %q", transition.From)
+	}
+	targetState, exists := stateMappings[transition.To]
+	if !exists {
+		return 0, 0, fmt.Errorf("transition to unknown state id %q", transition.To)
+	}
+	return sourceState, targetState, nil
+}
+
+//WriteNFA writes a NFA as a JFLAP finite automaton, layout may be nil
+func WriteNFA(w io.Writer, NFA *nfa.NFA, layout Layout) error {
+	automaton := new(jffAutomaton)
+	for index, state := range NFA.States {
+		automaton.States = append(automaton.States, writeState(index, state, layout, intArray.IndexOf(state, NFA.StartStates) != -1, intArray.IndexOf(state, NFA.AcceptStates) != -1))
+	}
+	for _, state := range NFA.States {
+		for _, transitionState := range NFA.EpsilonTransitions[state] {
+			automaton.Transitions = append(automaton.Transitions, writeTransition(state, transitionState, ""))
+		}
+		used := make([]string, 0, len(NFA.Transitions[state]))
+		for symbol := range NFA.Transitions[state] {
+			used = append(used, symbol)
+		}
+		for _, symbol := range sortedSymbols(NFA.Alphabet, used) {
+			for _, transitionState := range NFA.Transitions[state][symbol] {
+				automaton.Transitions = append(automaton.Transitions, writeTransition(state, transitionState, symbol))
+			}
+		}
+	}
+	return write(w, automaton)
+}
+
+//WriteDFA writes a DFA as a JFLAP finite automaton, layout may be nil
+func WriteDFA(w io.Writer, DFA *dfa.DFA, layout Layout) error {
+	automaton := new(jffAutomaton)
+	for index, state := range DFA.States {
+		automaton.States = append(automaton.States, writeState(index, state, layout, intArray.IndexOf(state, DFA.StartStates) != -1, intArray.IndexOf(state, DFA.AcceptStates) != -1))
+	}
+	for _, state := range DFA.States {
+		used := make([]string, 0, len(DFA.Transitions[state]))
+		for symbol := range DFA.Transitions[state] {
+			used = append(used, symbol)
+		}
+		for _, symbol := range sortedSymbols(DFA.Alphabet, used) {
+			automaton.Transitions = append(automaton.Transitions, writeTransition(state, DFA.Transitions[state][symbol], symbol))
+		}
+	}
+	return write(w, automaton)
+}
+
+//writeState places states without a known position on a grid so JFLAP can open the file
+func writeState(index, state int, layout Layout, isInitial, isFinal bool) jffState {
+	current, exists := layout[state]
+	if !exists || current.Name == "" {
+		current.Name = "q" + strconv.Itoa(state)
+	}
+	if !exists || !current.HasPosition {
+		current.X = float64(100 + 120*(index%6))
+		current.Y = float64(100 + 120*(index/6))
+	}
+	written := jffState{ID: strconv.Itoa(state), Name: current.Name, X: &current.X, Y: &current.Y}
+	if isInitial {
+		written.Initial = &struct{}{}
+	}
+	if isFinal {
+		written.Final = &struct{}{}
+	}
+	return written
+}
+
+func writeTransition(sourceState, targetState int, symbol string) jffTransition {
+	return jffTransition{From: strconv.Itoa(sourceState), To: strconv.Itoa(targetState), Read: &symbol}
+}
+
+//sortedSymbols of transitions, in alphabet order followed by the symbols missing from the alphabet in sorted order
+func sortedSymbols(alphabet []string, used []string) []string {
+	symbols := make([]string, 0, len(used))
+	for _, symbol := range alphabet {
+		if stringArray.IndexOf(symbol, used) != -1 {
+			symbols = append(symbols, symbol)
+		}
+	}
+	missing := make([]string, 0)
+	for _, symbol := range used {
+		if stringArray.IndexOf(symbol, symbols) == -1 {
+			missing = append(missing, symbol)
+		}
+	}
+	sort.Strings(missing)
+	return append(symbols, missing...)
+}
+
+func write(w io.Writer, automaton *jffAutomaton) error {
+	if _, err := io.WriteString(w, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>`+"\n"); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "\t")
+	if err := encoder.Encode(jffStructure{Type: "fa", Automaton: automaton}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,125 @@
+package lexer
+
+import (
+	"fmt"
+
+	"github.com/ChristopherCamara/finiteAutomata/dfa"
+	"github.com/ChristopherCamara/finiteAutomata/nfa"
+	regexparser "github.com/ChristopherCamara/finiteAutomata/regexParser"
+)
+
+//Error is the name of the token produced for input no rule recognises
+const Error = "ERROR"
+
+//Rule names the tokens matched by a regular expression
+type Rule struct {
+	Name    string
+	Pattern string
+}
+
+//Token found in the input, Line and Column start at 1 and Column counts runes
+type Token struct {
+	Name   string
+	Text   string
+	Offset int
+	Line   int
+	Column int
+}
+
+//Lexer splits input into tokens using the longest match, ties go to the earliest rule
+type Lexer struct {
+	Rules []Rule
+	DFA   *dfa.DFA
+}
+
+//New returns ready to use *Lexer for rules ordered from highest to lowest priority
+//
+//An error names the first rule whose pattern does not parse
+func New(rules []Rule) (*Lexer, error) {
+	parser := new(regexparser.RegexParser)
+	nfas := make([]*nfa.NFA, 0, len(rules))
+	for index, rule := range rules {
+		ruleNFA, err := parser.Parse(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %v", rule.Name, err)
+		}
+		ruleNFA.LabelAcceptStates(index)
+		nfas = append(nfas, ruleNFA)
+	}
+	combinedNFA := nfa.Combine(nfas...)
+	combinedNFA.Alphabet = parser.Alphabet
+	combinedDFA := dfa.FromNFA(combinedNFA)
+	//only the highest priority rule matters, keeping just that one lets more states merge
+	for state, labels := range combinedDFA.AcceptLabels {
+		combinedDFA.AcceptLabels[state] = labels[:1]
+	}
+	combinedDFA.Minimize()
+	return &Lexer{Rules: rules, DFA: combinedDFA}, nil
+}
+
+//Scanner produces the tokens of one input
+type Scanner struct {
+	lexer  *Lexer
+	input  string
+	offset int
+	line   int
+	column int
+}
+
+//Scan returns a *Scanner positioned at the start of input
+func (lexer *Lexer) Scan(input string) *Scanner {
+	return &Scanner{lexer: lexer, input: input, line: 1, column: 1}
+}
+
+//Next token of the input, false once the input is used up
+//
+//Input that no rule matches with at least one rune is returned one rune at a
+//time as a token named Error, scanning carries on after it
+func (scanner *Scanner) Next() (Token, bool) {
+	if scanner.offset >= len(scanner.input) {
+		return Token{}, false
+	}
+	runner := scanner.lexer.DFA.NewRunner()
+	end := -1
+	rule := -1
+	for offset := scanner.offset; offset < len(scanner.input); {
+		symbol, size := dfa.DecodeSymbol(scanner.input[offset:])
+		offset += size
+		if !runner.Step(symbol) {
+			break
+		}
+		if labels := runner.AcceptLabels(); len(labels) != 0 {
+			end = offset
+			rule = labels[0]
+		}
+	}
+	token := Token{Offset: scanner.offset, Line: scanner.line, Column: scanner.column}
+	if end == -1 {
+		_, size := dfa.DecodeSymbol(scanner.input[scanner.offset:])
+		end = scanner.offset + size
+		token.Name = Error
+	} else {
+		token.Name = scanner.lexer.Rules[rule].Name
+	}
+	token.Text = scanner.input[scanner.offset:end]
+	for _, symbol := range token.Text {
+		if symbol == '\n' {
+			scanner.line++
+			scanner.column = 1
+		} else {
+			scanner.column++
+		}
+	}
+	scanner.offset = end
+	return token, true
+}
+
+//Tokenize the whole input
+func (lexer *Lexer) Tokenize(input string) []Token {
+	tokens := make([]Token, 0)
+	scanner := lexer.Scan(input)
+	for token, ok := scanner.Next(); ok; token, ok = scanner.Next() {
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
@@ -0,0 +1,79 @@
+package lexer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newLexer(t *testing.T) *Lexer {
+	t.Helper()
+	lexer, err := New([]Rule{
+		{"IF", "if"},
+		{"IDENT", "(i|f|x)(i|f|x)*"},
+		{"NUMBER", "(0|1)(0|1)*"},
+		{"ASSIGN", "="},
+		{"EQUALS", "=="},
+		{"SPACE", "( |\n)( |\n)*"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lexer
+}
+
+func TestTokenPositions(t *testing.T) {
+	tokens := newLexer(t).Tokenize("xf = 10\nif\n  fx")
+	expected := []Token{
+		{"IDENT", "xf", 0, 1, 1},
+		{"SPACE", " ", 2, 1, 3},
+		{"ASSIGN", "=", 3, 1, 4},
+		{"SPACE", " ", 4, 1, 5},
+		{"NUMBER", "10", 5, 1, 6},
+		{"SPACE", "\n", 7, 1, 8},
+		{"IF", "if", 8, 2, 1},
+		{"SPACE", "\n  ", 10, 2, 3},
+		{"IDENT", "fx", 13, 3, 3},
+	}
+	if !reflect.DeepEqual(tokens, expected) {
+		t.Errorf("expected\n%v\ngot\n%v", expected, tokens)
+	}
+}
+
+func TestLongestMatchAndRuleOrder(t *testing.T) {
+	lexer := newLexer(t)
+	for input, expected := range map[string][]string{
+		//IF and IDENT both match "if", the earlier rule wins
+		"if": {"IF"},
+		//the longest match wins over the earlier rule
+		"iff": {"IDENT"},
+		"==":  {"EQUALS"},
+		"===": {"EQUALS", "ASSIGN"},
+	} {
+		names := make([]string, 0)
+		for _, token := range lexer.Tokenize(input) {
+			names = append(names, token.Name)
+		}
+		if !reflect.DeepEqual(names, expected) {
+			t.Errorf("%q: expected %v, got %v", input, expected, names)
+		}
+	}
+}
+
+func TestNoRuleMatches(t *testing.T) {
+	tokens := newLexer(t).Tokenize("x?€1")
+	expected := []Token{
+		{"IDENT", "x", 0, 1, 1},
+		{Error, "?", 1, 1, 2},
+		{Error, "€", 2, 1, 3},
+		{"NUMBER", "1", 5, 1, 4},
+	}
+	if !reflect.DeepEqual(tokens, expected) {
+		t.Errorf("expected\n%v\ngot\n%v", expected, tokens)
+	}
+}
+
+func TestNewInvalidRule(t *testing.T) {
+	if _, err := New([]Rule{{"IF", "if"}, {"BROKEN", "(if"}}); err == nil {
+		t.Error("expected an error for a rule that does not parse")
+	}
+}
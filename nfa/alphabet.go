@@ -0,0 +1,93 @@
+package nfa
+
+import (
+	"sort"
+
+	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+	"github.com/ChristopherCamara/finiteAutomata/internal/stringArray"
+)
+
+//SortedAlphabet returns a sorted copy of the Alphabet of a NFA
+func (nfa *NFA) SortedAlphabet() []string {
+	alphabet := append([]string(nil), nfa.Alphabet...)
+	sort.Strings(alphabet)
+	return alphabet
+}
+
+//ExtendAlphabet adds symbols that are not in the Alphabet yet
+//
+//A NFA needs no transition on every symbol, so no state is added
+func (nfa *NFA) ExtendAlphabet(symbols ...string) {
+	//NFAs parsed by one RegexParser share the Alphabet it keeps appending to
+	alphabet := append([]string(nil), nfa.Alphabet...)
+	for _, symbol := range symbols {
+		if stringArray.IndexOf(symbol, alphabet) == -1 {
+			alphabet = append(alphabet, symbol)
+		}
+	}
+	nfa.Alphabet = alphabet
+}
+
+//RestrictAlphabet keeps only the given symbols, transitions on any other symbol are removed
+func (nfa *NFA) RestrictAlphabet(symbols ...string) {
+	alphabet := make([]string, 0, len(symbols))
+	for _, symbol := range nfa.Alphabet {
+		if stringArray.IndexOf(symbol, symbols) != -1 {
+			alphabet = append(alphabet, symbol)
+		}
+	}
+	nfa.Alphabet = alphabet
+	for _, state := range nfa.States {
+		for symbol := range nfa.Transitions[state] {
+			if stringArray.IndexOf(symbol, symbols) == -1 {
+				delete(nfa.Transitions[state], symbol)
+			}
+		}
+	}
+}
+
+//RenameSymbols replaces every symbol found in renames by its new name
+//
+//Symbols renamed to the same name are merged, their transitions are kept
+//side by side. Symbols missing from renames keep their name
+func (nfa *NFA) RenameSymbols(renames map[string]string) {
+	rename := func(symbol string) string {
+		if renamed, exists := renames[symbol]; exists {
+			return renamed
+		}
+		return symbol
+	}
+	alphabet := make([]string, 0, len(nfa.Alphabet))
+	for _, symbol := range nfa.Alphabet {
+		if stringArray.IndexOf(rename(symbol), alphabet) == -1 {
+			alphabet = append(alphabet, rename(symbol))
+		}
+	}
+	nfa.Alphabet = alphabet
+	for _, state := range nfa.States {
+		transitions := make(map[string][]int, len(nfa.Transitions[state]))
+		for _, symbol := range nfa.sortedSymbols(state) {
+			for _, transitionState := range nfa.Transitions[state][symbol] {
+				if intArray.IndexOf(transitionState, transitions[rename(symbol)]) == -1 {
+					transitions[rename(symbol)] = append(transitions[rename(symbol)], transitionState)
+				}
+			}
+		}
+		nfa.Transitions[state] = transitions
+	}
+}
+
+//UnifyAlphabets extends the Alphabet of every NFA with the symbols of all the others
+func UnifyAlphabets(nfas ...*NFA) {
+	alphabet := make([]string, 0)
+	for _, current := range nfas {
+		for _, symbol := range current.Alphabet {
+			if stringArray.IndexOf(symbol, alphabet) == -1 {
+				alphabet = append(alphabet, symbol)
+			}
+		}
+	}
+	for _, current := range nfas {
+		current.ExtendAlphabet(alphabet...)
+	}
+}
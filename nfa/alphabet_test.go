@@ -0,0 +1,31 @@
+package nfa
+
+import (
+	"reflect"
+	"testing"
+)
+
+//UnifyAlphabets must give every NFA its own Alphabet, even when they started out sharing one
+func TestUnifyAlphabetsSharedAlphabet(t *testing.T) {
+	shared := make([]string, 0, 4)
+	shared = append(shared, "a", "b")
+	first, second, third := SymbolBasis("a"), SymbolBasis("b"), SymbolBasis("c")
+	first.Alphabet = shared
+	second.Alphabet = shared
+	third.Alphabet = []string{"c"}
+	states := len(first.States)
+	UnifyAlphabets(first, third)
+	second.ExtendAlphabet("d")
+	if expected := []string{"a", "b", "c"}; !reflect.DeepEqual(first.Alphabet, expected) {
+		t.Errorf("expected alphabet %v, got %v", expected, first.Alphabet)
+	}
+	if expected := []string{"c", "a", "b"}; !reflect.DeepEqual(third.Alphabet, expected) {
+		t.Errorf("expected alphabet %v, got %v", expected, third.Alphabet)
+	}
+	if expected := []string{"a", "b", "d"}; !reflect.DeepEqual(second.Alphabet, expected) {
+		t.Errorf("expected alphabet %v, got %v", expected, second.Alphabet)
+	}
+	if len(first.States) != states {
+		t.Errorf("extending the alphabet added states, %d instead of %d", len(first.States), states)
+	}
+}
@@ -0,0 +1,83 @@
+package nfa
+
+import (
+	"io"
+
+	"github.com/ChristopherCamara/finiteAutomata/internal/dot"
+	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+	"github.com/ChristopherCamara/finiteAutomata/internal/stringArray"
+)
+
+//DOTOptions change how WriteDOT draws an automaton
+type DOTOptions struct {
+	//StateLabels replace the state number shown inside a state
+	StateLabels map[int]string
+	//RankDir is the Graphviz rankdir, LR when empty
+	RankDir string
+	//Highlight states are drawn filled
+	Highlight []int
+	//SeparateEdges draws one edge per symbol instead of merging labels of edges between the same states
+	SeparateEdges bool
+}
+
+//WriteDOT writes a NFA as Graphviz DOT text, epsilon transitions are labeled ε
+func (nfa *NFA) WriteDOT(w io.Writer, opts DOTOptions) error {
+	graph := dot.Graph{RankDir: opts.RankDir, SeparateEdges: opts.SeparateEdges}
+	for _, state := range nfa.States {
+		graph.Nodes = append(graph.Nodes, dot.Node{
+			ID:        state,
+			Label:     opts.StateLabels[state],
+			Start:     intArray.IndexOf(state, nfa.StartStates) != -1,
+			Accept:    intArray.IndexOf(state, nfa.AcceptStates) != -1,
+			Highlight: intArray.IndexOf(state, opts.Highlight) != -1,
+		})
+	}
+	for _, state := range nfa.States {
+		for _, transitionState := range nfa.EpsilonTransitions[state] {
+			graph.Edges = append(graph.Edges, dot.Edge{From: state, To: transitionState, Label: "ε"})
+		}
+		for _, symbol := range nfa.sortedSymbols(state) {
+			for _, transitionState := range nfa.Transitions[state][symbol] {
+				graph.Edges = append(graph.Edges, dot.Edge{From: state, To: transitionState, Label: symbol})
+			}
+		}
+	}
+	return graph.Write(w)
+}
+
+//ReadDOT builds a NFA from a DOT digraph in the shape WriteDOT and SaveGraphviz produce
+//
+//States are numbered in the order their nodes first appear, symbols are added
+//to the Alphabet in the order they are first seen and ε or λ edges become
+//epsilon transitions
+func ReadDOT(r io.Reader) (*NFA, error) {
+	automaton, err := dot.ReadAutomaton(r)
+	if err != nil {
+		return nil, err
+	}
+	newNFA := New()
+	stateMappings := make(map[string]int)
+	for _, name := range automaton.States {
+		stateMappings[name] = newNFA.AddState(false, false)
+	}
+	for _, name := range automaton.StartStates {
+		newNFA.StartStates = append(newNFA.StartStates, stateMappings[name])
+	}
+	for _, name := range automaton.AcceptStates {
+		newNFA.AcceptStates = append(newNFA.AcceptStates, stateMappings[name])
+	}
+	for _, transition := range automaton.Transitions {
+		if transition.Epsilon {
+			newNFA.AddEpsilonTransition(stateMappings[transition.From], stateMappings[transition.To])
+			continue
+		}
+		if stringArray.IndexOf(transition.Symbol, newNFA.Alphabet) == -1 {
+			newNFA.Alphabet = append(newNFA.Alphabet, transition.Symbol)
+		}
+		newNFA.AddTransition(stateMappings[transition.From], transition.Symbol, stateMappings[transition.To])
+	}
+	if err := newNFA.Validate(); err != nil {
+		return nil, err
+	}
+	return newNFA, nil
+}
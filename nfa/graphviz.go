@@ -0,0 +1,32 @@
+//go:build cgo
+// +build cgo
+
+package nfa
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/ChristopherCamara/finiteAutomata/internal/render"
+)
+
+//Render a NFA as an image using Graphviz
+func (nfa *NFA) Render(w io.Writer, format Format, opts RenderOptions) error {
+	if err := opts.Check(format); err != nil {
+		return err
+	}
+	var dotText bytes.Buffer
+	if err := nfa.WriteDOT(&dotText, opts.DOTOptions); err != nil {
+		return err
+	}
+	return render.DOT(dotText.Bytes(), w, string(format), string(opts.Layout))
+}
+
+//SaveGraphviz renders a NFA as SVG into fileName with .svg appended, no file is written when rendering fails
+//
+//Deprecated: use Render, which lets the caller pick the format and destination
+func (nfa *NFA) SaveGraphviz(fileName string) error {
+	return render.File(fileName+".svg", func(w io.Writer) error {
+		return nfa.Render(w, SVG, RenderOptions{})
+	})
+}
@@ -0,0 +1,61 @@
+package nfa
+
+import (
+	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+	"github.com/ChristopherCamara/finiteAutomata/internal/stringArray"
+)
+
+//Homomorphism returns a NFA for the image of the language of n under h
+//
+//Every transition on a symbol is replaced by a chain of transitions spelling
+//the word h maps the symbol to, an empty word becomes an epsilon transition.
+//Symbols missing from h are mapped to themselves
+func Homomorphism(n *NFA, h map[string][]string) *NFA {
+	image := func(symbol string) []string {
+		if word, exists := h[symbol]; exists {
+			return word
+		}
+		return []string{symbol}
+	}
+	newNFA := New()
+	stateMappings := make(map[int]int)
+	for _, state := range n.States {
+		stateMappings[state] = newNFA.AddState(intArray.IndexOf(state, n.StartStates) != -1, intArray.IndexOf(state, n.AcceptStates) != -1)
+		if labels, exists := n.AcceptLabels[state]; exists {
+			newNFA.AcceptLabels[stateMappings[state]] = append([]int(nil), labels...)
+		}
+	}
+	for _, symbol := range n.Alphabet {
+		for _, imageSymbol := range image(symbol) {
+			if stringArray.IndexOf(imageSymbol, newNFA.Alphabet) == -1 {
+				newNFA.Alphabet = append(newNFA.Alphabet, imageSymbol)
+			}
+		}
+	}
+	for _, state := range n.States {
+		for _, transitionState := range n.EpsilonTransitions[state] {
+			newNFA.AddEpsilonTransition(stateMappings[state], stateMappings[transitionState])
+		}
+		for _, symbol := range n.sortedSymbols(state) {
+			word := image(symbol)
+			for _, transitionState := range n.Transitions[state][symbol] {
+				if len(word) == 0 {
+					newNFA.AddEpsilonTransition(stateMappings[state], stateMappings[transitionState])
+					continue
+				}
+				//spell out the word through fresh intermediate states
+				currentState := stateMappings[state]
+				for index, imageSymbol := range word {
+					nextState := stateMappings[transitionState]
+					if index != len(word)-1 {
+						nextState = newNFA.AddState(false, false)
+					}
+					newNFA.AddTransition(currentState, imageSymbol, nextState)
+					currentState = nextState
+				}
+				newNFA.ExtendAlphabet(word...)
+			}
+		}
+	}
+	return newNFA
+}
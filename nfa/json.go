@@ -0,0 +1,122 @@
+package nfa
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+//JSONVersion is the schema version written by MarshalJSON and accepted by UnmarshalJSON
+//
+//Version 1 of the schema looks like
+//
+//	{
+//		"version": 1,
+//		"alphabet": ["a", "b"],
+//		"states": [0, 1],
+//		"start": [0],
+//		"accept": [1],
+//		"transitions": [{"from": 0, "symbol": "a", "to": [1]}],
+//		"epsilon": [{"from": 1, "to": [0]}],
+//		"labels": [{"state": 1, "labels": [0]}]
+//	}
+//
+//"labels" holds AcceptLabels and may be left out
+const JSONVersion = 1
+
+type jsonNFA struct {
+	Version     int                `json:"version"`
+	Alphabet    []string           `json:"alphabet"`
+	States      []int              `json:"states"`
+	Start       []int              `json:"start"`
+	Accept      []int              `json:"accept"`
+	Transitions []jsonTransition   `json:"transitions"`
+	Epsilon     []jsonEpsilon      `json:"epsilon"`
+	Labels      []jsonAcceptLabels `json:"labels,omitempty"`
+}
+
+type jsonTransition struct {
+	From   int    `json:"from"`
+	Symbol string `json:"symbol"`
+	To     []int  `json:"to"`
+}
+
+type jsonEpsilon struct {
+	From int   `json:"from"`
+	To   []int `json:"to"`
+}
+
+type jsonAcceptLabels struct {
+	State  int   `json:"state"`
+	Labels []int `json:"labels"`
+}
+
+//MarshalJSON encodes a NFA using the schema described by JSONVersion
+func (nfa *NFA) MarshalJSON() ([]byte, error) {
+	encoded := jsonNFA{
+		Version:     JSONVersion,
+		Alphabet:    nfa.Alphabet,
+		States:      nfa.States,
+		Start:       nfa.StartStates,
+		Accept:      nfa.AcceptStates,
+		Transitions: make([]jsonTransition, 0),
+		Epsilon:     make([]jsonEpsilon, 0),
+	}
+	for _, state := range nfa.States {
+		for _, symbol := range nfa.sortedSymbols(state) {
+			if len(nfa.Transitions[state][symbol]) != 0 {
+				encoded.Transitions = append(encoded.Transitions, jsonTransition{From: state, Symbol: symbol, To: nfa.Transitions[state][symbol]})
+			}
+		}
+		if len(nfa.EpsilonTransitions[state]) != 0 {
+			encoded.Epsilon = append(encoded.Epsilon, jsonEpsilon{From: state, To: nfa.EpsilonTransitions[state]})
+		}
+		if labels, exists := nfa.AcceptLabels[state]; exists {
+			encoded.Labels = append(encoded.Labels, jsonAcceptLabels{State: state, Labels: labels})
+		}
+	}
+	return json.Marshal(encoded)
+}
+
+//UnmarshalJSON decodes a NFA written with the schema described by JSONVersion and validates it
+func (nfa *NFA) UnmarshalJSON(data []byte) error {
+	var decoded jsonNFA
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if decoded.Version != JSONVersion {
+		return fmt.Errorf("unsupported NFA JSON version %d", decoded.Version)
+	}
+	newNFA := New()
+	newNFA.Alphabet = append(newNFA.Alphabet, decoded.Alphabet...)
+	newNFA.States = append(newNFA.States, decoded.States...)
+	newNFA.StartStates = append(newNFA.StartStates, decoded.Start...)
+	newNFA.AcceptStates = append(newNFA.AcceptStates, decoded.Accept...)
+	for _, state := range newNFA.States {
+		newNFA.Transitions[state] = make(map[string][]int, 0)
+		newNFA.EpsilonTransitions[state] = make([]int, 0)
+		if state >= newNFA.nextState {
+			newNFA.nextState = state + 1
+		}
+	}
+	for _, transition := range decoded.Transitions {
+		if newNFA.Transitions[transition.From] == nil {
+			newNFA.Transitions[transition.From] = make(map[string][]int, 0)
+		}
+		for _, targetState := range transition.To {
+			newNFA.AddTransition(transition.From, transition.Symbol, targetState)
+		}
+	}
+	for _, epsilon := range decoded.Epsilon {
+		for _, targetState := range epsilon.To {
+			newNFA.AddEpsilonTransition(epsilon.From, targetState)
+		}
+	}
+	for _, acceptLabels := range decoded.Labels {
+		newNFA.AcceptLabels[acceptLabels.State] = acceptLabels.Labels
+	}
+	if err := newNFA.Validate(); err != nil {
+		return err
+	}
+	*nfa = *newNFA
+	return nil
+}
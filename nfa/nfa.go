@@ -2,13 +2,10 @@ package nfa
 
 import (
 	"fmt"
-	"log"
-	"strconv"
-	"strings"
+	"sort"
 
 	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
-	"github.com/goccy/go-graphviz"
-	"github.com/goccy/go-graphviz/cgraph"
+	"github.com/ChristopherCamara/finiteAutomata/internal/stringArray"
 )
 
 //NFA non-deterministic finite automata struct definition
@@ -20,11 +17,7 @@ type NFA struct {
 	AcceptStates       []int
 	Transitions        map[int]map[string][]int
 	EpsilonTransitions map[int][]int
-}
-
-type edge struct {
-	edge  *cgraph.Edge
-	label string
+	AcceptLabels       map[int][]int
 }
 
 //New returns ready to use *NFA
@@ -37,92 +30,10 @@ func New() *NFA {
 	newNFA.AcceptStates = make([]int, 0)
 	newNFA.Transitions = make(map[int]map[string][]int, 0)
 	newNFA.EpsilonTransitions = make(map[int][]int, 0)
+	newNFA.AcceptLabels = make(map[int][]int, 0)
 	return newNFA
 }
 
-//SaveGraphviz image file
-func (nfa *NFA) SaveGraphviz(fileName string) {
-	fileName = strings.ReplaceAll(fileName, "*", "star")
-	fileName = strings.ReplaceAll(fileName, "|", " or ")
-	g := graphviz.New()
-	graph, err := g.Graph()
-	if err != nil {
-		log.Fatal(err)
-	}
-	graph.SetLayout("dot")
-	graph.SetRankDir(cgraph.LRRank)
-	defer func() {
-		if err := graph.Close(); err != nil {
-			log.Fatal(err)
-		}
-	}()
-	nodeMappings := make(map[int]*cgraph.Node)
-	edgeMappings := make(map[int]map[int]*edge)
-	for _, currentState := range nfa.States {
-		newNode, err := graph.CreateNode(strconv.Itoa(currentState))
-		if err != nil {
-			log.Panic(err)
-		}
-		if intArray.IndexOf(currentState, nfa.StartStates) != -1 {
-			nilNode, err := graph.CreateNode("")
-			if err != nil {
-				log.Panic(err)
-			}
-			nilNode.SetShape(cgraph.NoneShape)
-			_, err = graph.CreateEdge("", nilNode, newNode)
-			if err != nil {
-				log.Panic(err)
-			}
-		}
-		if intArray.IndexOf(currentState, nfa.AcceptStates) != -1 {
-			newNode.SetShape(cgraph.DoubleCircleShape)
-		} else {
-			newNode.SetShape(cgraph.CircleShape)
-		}
-		nodeMappings[currentState] = newNode
-	}
-	for _, currentState := range nfa.States {
-		for _, transitionState := range nfa.EpsilonTransitions[currentState] {
-			newEdge, err := graph.CreateEdge("", nodeMappings[currentState], nodeMappings[transitionState])
-			if err != nil {
-				log.Panic(err)
-			}
-			newEdge.SetLabel("ε")
-			if edgeMappings[currentState] == nil {
-				edgeMappings[currentState] = make(map[int]*edge)
-				edgeMappings[currentState][transitionState] = &edge{edge: newEdge, label: "ε"}
-			} else if currentEdge, exists := edgeMappings[currentState][transitionState]; exists {
-				currentEdge.label = currentEdge.label + ",ε"
-				currentEdge.edge.SetLabel(currentEdge.label)
-			} else {
-				edgeMappings[currentState][transitionState] = &edge{edge: newEdge, label: "ε"}
-			}
-		}
-		for symbol, transitionStates := range nfa.Transitions[currentState] {
-			for _, transitionState := range transitionStates {
-				newEdge, err := graph.CreateEdge("", nodeMappings[currentState], nodeMappings[transitionState])
-				if err != nil {
-					log.Panic(err)
-				}
-				newEdge.SetLabel(symbol)
-				if edgeMappings[currentState] == nil {
-					edgeMappings[currentState] = make(map[int]*edge)
-					edgeMappings[currentState][transitionState] = &edge{edge: newEdge, label: symbol}
-				} else if currentEdge, exists := edgeMappings[currentState][transitionState]; exists {
-					currentEdge.label = currentEdge.label + "," + symbol
-					currentEdge.edge.SetLabel(currentEdge.label)
-				} else {
-					edgeMappings[currentState][transitionState] = &edge{edge: newEdge, label: symbol}
-				}
-			}
-		}
-	}
-	err = g.RenderFilename(graph, graphviz.SVG, fileName+".svg")
-	if err != nil {
-		log.Fatal(err)
-	}
-}
-
 //RemoveState from a NFA
 func (nfa *NFA) RemoveState(removeState int) {
 	intArray.Remove(removeState, &nfa.States)
@@ -130,6 +41,15 @@ func (nfa *NFA) RemoveState(removeState int) {
 	intArray.Remove(removeState, &nfa.AcceptStates)
 	delete(nfa.Transitions, removeState)
 	delete(nfa.EpsilonTransitions, removeState)
+	acceptLabels := make(map[int][]int, len(nfa.AcceptLabels))
+	for state, labels := range nfa.AcceptLabels {
+		if state > removeState {
+			acceptLabels[state-1] = labels
+		} else if state < removeState {
+			acceptLabels[state] = labels
+		}
+	}
+	nfa.AcceptLabels = acceptLabels
 	for i := 0; i < len(nfa.States); i++ {
 		currentState := nfa.States[i]
 		for symbol, transitions := range nfa.Transitions[currentState] {
@@ -160,6 +80,9 @@ func (nfa *NFA) RemoveState(removeState int) {
 			nfa.AcceptStates[i]--
 		}
 	}
+	//the highest state has moved down by one, drop what is left under its old number
+	delete(nfa.Transitions, nfa.nextState-1)
+	delete(nfa.EpsilonTransitions, nfa.nextState-1)
 	nfa.nextState--
 }
 
@@ -196,19 +119,25 @@ func (nfa *NFA) merge(other *NFA) map[int]int {
 		newStates[otherState] = nfa.AddState(false, false)
 	}
 	//update epsilon transitions to new states
-	for otherState, transitionStates := range other.EpsilonTransitions {
-		for _, transitionState := range transitionStates {
+	for _, otherState := range other.States {
+		for _, transitionState := range other.EpsilonTransitions[otherState] {
 			nfa.AddEpsilonTransition(newStates[otherState], newStates[transitionState])
 		}
 	}
 	//update transitions to new states
-	for otherState, transitions := range other.Transitions {
-		for symbol, transitionStates := range transitions {
-			for _, transitionState := range transitionStates {
+	for _, otherState := range other.States {
+		for _, symbol := range other.sortedSymbols(otherState) {
+			for _, transitionState := range other.Transitions[otherState][symbol] {
 				nfa.AddTransition(newStates[otherState], symbol, newStates[transitionState])
 			}
 		}
 	}
+	//carry over accept labels to new states
+	for _, otherState := range other.States {
+		if labels, exists := other.AcceptLabels[otherState]; exists {
+			nfa.AcceptLabels[newStates[otherState]] = append([]int(nil), labels...)
+		}
+	}
 	return newStates
 }
 
@@ -222,8 +151,8 @@ func (nfa *NFA) Concat(other *NFA) {
 				nfa.AddEpsilonTransition(acceptState, newStates[otherTransition])
 			}
 		}
-		for symbol, otherTransitions := range other.Transitions[otherStart] {
-			for _, otherTransition := range otherTransitions {
+		for _, symbol := range other.sortedSymbols(otherStart) {
+			for _, otherTransition := range other.Transitions[otherStart][symbol] {
 				for _, acceptState := range nfa.AcceptStates {
 					nfa.AddTransition(acceptState, symbol, newStates[otherTransition])
 				}
@@ -263,6 +192,35 @@ func (nfa *NFA) Union(other *NFA) {
 	*nfa = *newNFA
 }
 
+//Combine NFAs under a single new start state, keeping every accept state and its labels
+func Combine(nfas ...*NFA) *NFA {
+	newNFA := New()
+	newStart := newNFA.AddState(true, false)
+	for _, other := range nfas {
+		newStates := newNFA.merge(other)
+		for _, otherStart := range other.StartStates {
+			newNFA.AddEpsilonTransition(newStart, newStates[otherStart])
+		}
+		for _, otherAccept := range other.AcceptStates {
+			newNFA.AcceptStates = append(newNFA.AcceptStates, newStates[otherAccept])
+		}
+		for _, symbol := range other.Alphabet {
+			if stringArray.IndexOf(symbol, newNFA.Alphabet) == -1 {
+				newNFA.Alphabet = append(newNFA.Alphabet, symbol)
+			}
+		}
+	}
+	return newNFA
+}
+
+//LabelAcceptStates tags every accept state of a NFA with label, replacing earlier labels
+func (nfa *NFA) LabelAcceptStates(label int) {
+	nfa.AcceptLabels = make(map[int][]int, len(nfa.AcceptStates))
+	for _, acceptState := range nfa.AcceptStates {
+		nfa.AcceptLabels[acceptState] = []int{label}
+	}
+}
+
 //Closure of a NFA
 func (nfa *NFA) Closure() {
 	newNFA := New()
@@ -282,6 +240,16 @@ func (nfa *NFA) Closure() {
 	*nfa = *newNFA
 }
 
+//sortedSymbols of the transitions leaving state
+func (nfa *NFA) sortedSymbols(state int) []string {
+	symbols := make([]string, 0, len(nfa.Transitions[state]))
+	for symbol := range nfa.Transitions[state] {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
 //Print out NFA information
 func (nfa *NFA) Print() {
 	fmt.Println("~~~NFA~~~")
@@ -290,9 +258,9 @@ func (nfa *NFA) Print() {
 	for _, state := range nfa.States {
 		fmt.Printf("state %d:\n", state)
 		if nfa.Transitions[state] != nil {
-			for symbol, states := range nfa.Transitions[state] {
+			for _, symbol := range nfa.sortedSymbols(state) {
 				fmt.Printf("\t%s -> ", symbol)
-				intArray.Print(states)
+				intArray.Print(nfa.Transitions[state][symbol])
 			}
 		}
 		if len(nfa.EpsilonTransitions[state]) != 0 {
@@ -0,0 +1,49 @@
+package nfa
+
+import "fmt"
+
+//Format of an image made by Render
+type Format string
+
+const (
+	//SVG vector image
+	SVG Format = "svg"
+	//PNG raster image
+	PNG Format = "png"
+	//JPG raster image
+	JPG Format = "jpg"
+)
+
+//Layout is the Graphviz engine placing the states of an image made by Render
+type Layout string
+
+const (
+	//DotLayout draws transitions flowing in one direction, the default
+	DotLayout Layout = "dot"
+	//CircoLayout places states on a circle
+	CircoLayout Layout = "circo"
+	//NeatoLayout places states with a spring model
+	NeatoLayout Layout = "neato"
+)
+
+//RenderOptions change how Render draws an automaton
+type RenderOptions struct {
+	DOTOptions
+	//Layout engine, DotLayout when empty
+	Layout Layout
+}
+
+//Check returns an error for a format or layout that Render does not support
+func (opts RenderOptions) Check(format Format) error {
+	switch format {
+	case SVG, PNG, JPG:
+	default:
+		return fmt.Errorf("unsupported image format %q", format)
+	}
+	switch opts.Layout {
+	case "", DotLayout, CircoLayout, NeatoLayout:
+	default:
+		return fmt.Errorf("unsupported layout %q", opts.Layout)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package nfa
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+	"github.com/ChristopherCamara/finiteAutomata/internal/stringArray"
+)
+
+//Problem found by Validate, Location names the offending field like Transitions[0]["a"][1]
+type Problem struct {
+	Location string
+	Message  string
+}
+
+//ValidationError lists every Problem found by Validate
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (err *ValidationError) Error() string {
+	messages := make([]string, 0, len(err.Problems))
+	for _, problem := range err.Problems {
+		messages = append(messages, problem.Location+": "+problem.Message)
+	}
+	return fmt.Sprintf("invalid NFA, %d problem(s): %s", len(err.Problems), strings.Join(messages, "; "))
+}
+
+func (err *ValidationError) add(location, format string, args ...interface{}) {
+	err.Problems = append(err.Problems, Problem{Location: location, Message: fmt.Sprintf(format, args...)})
+}
+
+//Validate reports every inconsistency between the exported fields of a NFA as a *ValidationError
+func (nfa *NFA) Validate() error {
+	err := new(ValidationError)
+	for index, symbol := range nfa.Alphabet {
+		if stringArray.IndexOf(symbol, nfa.Alphabet) != index {
+			err.add(fmt.Sprintf("Alphabet[%d]", index), "duplicate symbol %q", symbol)
+		}
+	}
+	for index, state := range nfa.States {
+		if intArray.IndexOf(state, nfa.States) != index {
+			err.add(fmt.Sprintf("States[%d]", index), "duplicate state %d", state)
+		}
+		if _, exists := nfa.Transitions[state]; !exists {
+			err.add(fmt.Sprintf("Transitions[%d]", state), "missing transition map for state %d", state)
+		}
+	}
+	if len(nfa.StartStates) == 0 {
+		err.add("StartStates", "no start state")
+	}
+	for index, state := range nfa.StartStates {
+		if intArray.IndexOf(state, nfa.States) == -1 {
+			err.add(fmt.Sprintf("StartStates[%d]", index), "unknown state %d", state)
+		}
+	}
+	for index, state := range nfa.AcceptStates {
+		if intArray.IndexOf(state, nfa.States) == -1 {
+			err.add(fmt.Sprintf("AcceptStates[%d]", index), "unknown state %d", state)
+		}
+	}
+	sources := make([]int, 0, len(nfa.Transitions))
+	for state := range nfa.Transitions {
+		sources = append(sources, state)
+	}
+	sort.Ints(sources)
+	for _, state := range sources {
+		location := fmt.Sprintf("Transitions[%d]", state)
+		if intArray.IndexOf(state, nfa.States) == -1 {
+			err.add(location, "transitions from unknown state %d", state)
+		}
+		symbols := make([]string, 0, len(nfa.Transitions[state]))
+		for symbol := range nfa.Transitions[state] {
+			symbols = append(symbols, symbol)
+		}
+		sort.Strings(symbols)
+		for _, symbol := range symbols {
+			symbolLocation := fmt.Sprintf("%s[%q]", location, symbol)
+			if stringArray.IndexOf(symbol, nfa.Alphabet) == -1 {
+				err.add(symbolLocation, "symbol %q not in alphabet", symbol)
+			}
+			for index, targetState := range nfa.Transitions[state][symbol] {
+				if intArray.IndexOf(targetState, nfa.States) == -1 {
+					err.add(fmt.Sprintf("%s[%d]", symbolLocation, index), "transition to unknown state %d", targetState)
+				}
+			}
+		}
+	}
+	epsilonSources := make([]int, 0, len(nfa.EpsilonTransitions))
+	for state := range nfa.EpsilonTransitions {
+		epsilonSources = append(epsilonSources, state)
+	}
+	sort.Ints(epsilonSources)
+	for _, state := range epsilonSources {
+		location := fmt.Sprintf("EpsilonTransitions[%d]", state)
+		if intArray.IndexOf(state, nfa.States) == -1 {
+			err.add(location, "epsilon transitions from unknown state %d", state)
+		}
+		for index, targetState := range nfa.EpsilonTransitions[state] {
+			if intArray.IndexOf(targetState, nfa.States) == -1 {
+				err.add(fmt.Sprintf("%s[%d]", location, index), "epsilon transition to unknown state %d", targetState)
+			}
+		}
+	}
+	labeledStates := make([]int, 0, len(nfa.AcceptLabels))
+	for state := range nfa.AcceptLabels {
+		labeledStates = append(labeledStates, state)
+	}
+	sort.Ints(labeledStates)
+	for _, state := range labeledStates {
+		if intArray.IndexOf(state, nfa.AcceptStates) == -1 {
+			err.add(fmt.Sprintf("AcceptLabels[%d]", state), "labels on state %d which is not an accept state", state)
+		}
+	}
+	if len(err.Problems) != 0 {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,296 @@
+package openfst
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ChristopherCamara/finiteAutomata/dfa"
+	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+	"github.com/ChristopherCamara/finiteAutomata/internal/stringArray"
+	"github.com/ChristopherCamara/finiteAutomata/nfa"
+)
+
+//Options for reading and writing AT&T FSM text
+type Options struct {
+	//Symbols maps symbols to the integer IDs arcs are labelled with, as fstcompile
+	//expects. When nil labels are symbols written out literally except for 0 and
+	//<eps>, which both stand for epsilon like in fstprint output
+	Symbols *SymbolTable
+	//Acceptor arcs have a single label, "src dst label [weight]", otherwise arcs are "src dst ilabel olabel [weight]"
+	Acceptor bool
+}
+
+type arc struct {
+	from    int
+	to      int
+	symbol  string
+	epsilon bool
+}
+
+//fsm is what AT&T text describes once weights and output labels are dropped
+type fsm struct {
+	states []int
+	start  int
+	arcs   []arc
+	finals []int
+}
+
+//read AT&T FSM text, the source of the first line is the start state
+//
+//Arcs and final states whose weight is infinite are left out since they can never
+//be taken, other weights and output labels are ignored
+func read(r io.Reader, opts Options) (*fsm, error) {
+	machine := &fsm{start: -1}
+	labelFields := 2
+	if opts.Acceptor {
+		labelFields = 1
+	}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		source, err := strconv.Atoi(fields[0])
+		if err != nil || source < 0 {
+			return nil, fmt.Errorf("line %d: invalid state %q", line, fields[0])
+		}
+		if machine.start == -1 {
+			machine.start = source
+		}
+		machine.addState(source)
+		switch {
+		case len(fields) <= 2:
+			if len(fields) == 2 && isInfinite(fields[1]) {
+				continue
+			}
+			if len(fields) == 2 {
+				if _, err := strconv.ParseFloat(fields[1], 64); err != nil {
+					return nil, fmt.Errorf("line %d: invalid final weight %q", line, fields[1])
+				}
+			}
+			if intArray.IndexOf(source, machine.finals) == -1 {
+				machine.finals = append(machine.finals, source)
+			}
+		case len(fields) == 2+labelFields || len(fields) == 3+labelFields:
+			target, err := strconv.Atoi(fields[1])
+			if err != nil || target < 0 {
+				return nil, fmt.Errorf("line %d: invalid state %q", line, fields[1])
+			}
+			machine.addState(target)
+			if len(fields) == 3+labelFields && isInfinite(fields[2+labelFields]) {
+				continue
+			}
+			symbol, epsilon, err := readLabel(fields[2], opts.Symbols)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", line, err)
+			}
+			machine.arcs = append(machine.arcs, arc{from: source, to: target, symbol: symbol, epsilon: epsilon})
+		default:
+			return nil, fmt.Errorf("line %d: unexpected number of fields %d", line, len(fields))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return machine, nil
+}
+
+func (machine *fsm) addState(state int) {
+	if intArray.IndexOf(state, machine.states) == -1 {
+		machine.states = append(machine.states, state)
+	}
+}
+
+func isInfinite(weight string) bool {
+	value, err := strconv.ParseFloat(weight, 64)
+	return err == nil && math.IsInf(value, 1)
+}
+
+func readLabel(label string, symbols *SymbolTable) (string, bool, error) {
+	if symbols == nil {
+		return label, label == Epsilon || label == "0", nil
+	}
+	id, err := strconv.Atoi(label)
+	if err != nil {
+		return "", false, fmt.Errorf("label %q is not an integer id", label)
+	}
+	symbol, exists := symbols.Symbol(id)
+	if !exists {
+		return "", false, fmt.Errorf("label %d not in symbol table", id)
+	}
+	return symbol, id == 0, nil
+}
+
+//ReadNFA reads AT&T FSM text into a NFA, epsilon labels become epsilon transitions
+//
+//States are numbered in the order they first appear, so the start state is 0
+func ReadNFA(r io.Reader, opts Options) (*nfa.NFA, error) {
+	machine, err := read(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	newNFA := nfa.New()
+	stateMappings := make(map[int]int)
+	for _, state := range machine.states {
+		stateMappings[state] = newNFA.AddState(state == machine.start, intArray.IndexOf(state, machine.finals) != -1)
+	}
+	for _, current := range machine.arcs {
+		if current.epsilon {
+			newNFA.AddEpsilonTransition(stateMappings[current.from], stateMappings[current.to])
+			continue
+		}
+		if stringArray.IndexOf(current.symbol, newNFA.Alphabet) == -1 {
+			newNFA.Alphabet = append(newNFA.Alphabet, current.symbol)
+		}
+		newNFA.AddTransition(stateMappings[current.from], current.symbol, stateMappings[current.to])
+	}
+	if err := newNFA.Validate(); err != nil {
+		return nil, err
+	}
+	return newNFA, nil
+}
+
+//ReadDFA reads AT&T FSM text that must be deterministic into a DFA
+//
+//States are numbered in the order they first appear, so the start state is 0
+func ReadDFA(r io.Reader, opts Options) (*dfa.DFA, error) {
+	machine, err := read(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	newDFA := dfa.New()
+	stateMappings := make(map[int]int)
+	for _, state := range machine.states {
+		stateMappings[state] = newDFA.AddState(state == machine.start, intArray.IndexOf(state, machine.finals) != -1)
+	}
+	for _, current := range machine.arcs {
+		if current.epsilon {
+			return nil, fmt.Errorf("epsilon arc from state %d to %d in a DFA", current.from, current.to)
+		}
+		if _, exists := newDFA.Transitions[stateMappings[current.from]][current.symbol]; exists {
+			return nil, fmt.Errorf("state %d has more than one arc on %q", current.from, current.symbol)
+		}
+		if stringArray.IndexOf(current.symbol, newDFA.Alphabet) == -1 {
+			newDFA.Alphabet = append(newDFA.Alphabet, current.symbol)
+		}
+		newDFA.AddTransition(stateMappings[current.from], current.symbol, stateMappings[current.to])
+	}
+	if err := newDFA.Validate(); err != nil {
+		return nil, err
+	}
+	return newDFA, nil
+}
+
+//WriteNFA writes a NFA with a single start state as AT&T FSM text
+func WriteNFA(w io.Writer, NFA *nfa.NFA, opts Options) error {
+	if len(NFA.StartStates) != 1 {
+		return fmt.Errorf("AT&T FSM text needs exactly one start state, found %d", len(NFA.StartStates))
+	}
+	machine := &fsm{start: NFA.StartStates[0], finals: NFA.AcceptStates}
+	for _, state := range NFA.States {
+		for _, transitionState := range NFA.EpsilonTransitions[state] {
+			machine.arcs = append(machine.arcs, arc{from: state, to: transitionState, epsilon: true})
+		}
+		symbols := make([]string, 0, len(NFA.Transitions[state]))
+		for symbol := range NFA.Transitions[state] {
+			symbols = append(symbols, symbol)
+		}
+		sort.Strings(symbols)
+		for _, symbol := range symbols {
+			for _, transitionState := range NFA.Transitions[state][symbol] {
+				machine.arcs = append(machine.arcs, arc{from: state, to: transitionState, symbol: symbol})
+			}
+		}
+	}
+	return write(w, machine, opts)
+}
+
+//WriteDFA writes a DFA as AT&T FSM text
+func WriteDFA(w io.Writer, DFA *dfa.DFA, opts Options) error {
+	if len(DFA.StartStates) != 1 {
+		return fmt.Errorf("AT&T FSM text needs exactly one start state, found %d", len(DFA.StartStates))
+	}
+	machine := &fsm{start: DFA.StartStates[0], finals: DFA.AcceptStates}
+	for _, state := range DFA.States {
+		symbols := make([]string, 0, len(DFA.Transitions[state]))
+		for symbol := range DFA.Transitions[state] {
+			symbols = append(symbols, symbol)
+		}
+		sort.Strings(symbols)
+		for _, symbol := range symbols {
+			machine.arcs = append(machine.arcs, arc{from: state, to: DFA.Transitions[state][symbol], symbol: symbol})
+		}
+	}
+	return write(w, machine, opts)
+}
+
+//write arcs leaving the start state first since the first line names the start state
+//
+//When the start state has no arcs and does not accept the language is empty and nothing is written
+func write(w io.Writer, machine *fsm, opts Options) error {
+	writer := bufio.NewWriter(w)
+	startArcs := make([]arc, 0)
+	otherArcs := make([]arc, 0)
+	for _, current := range machine.arcs {
+		if current.from == machine.start {
+			startArcs = append(startArcs, current)
+		} else {
+			otherArcs = append(otherArcs, current)
+		}
+	}
+	startFinal := intArray.IndexOf(machine.start, machine.finals) != -1
+	if len(startArcs) == 0 && !startFinal {
+		return writer.Flush()
+	}
+	if len(startArcs) == 0 {
+		fmt.Fprintf(writer, "%d\n", machine.start)
+	}
+	for _, current := range append(startArcs, otherArcs...) {
+		label, err := writeLabel(current, opts.Symbols)
+		if err != nil {
+			return err
+		}
+		if opts.Acceptor {
+			fmt.Fprintf(writer, "%d\t%d\t%s\n", current.from, current.to, label)
+		} else {
+			fmt.Fprintf(writer, "%d\t%d\t%s\t%s\n", current.from, current.to, label, label)
+		}
+	}
+	for _, state := range machine.finals {
+		if state != machine.start || len(startArcs) != 0 {
+			fmt.Fprintf(writer, "%d\n", state)
+		}
+	}
+	return writer.Flush()
+}
+
+func writeLabel(current arc, symbols *SymbolTable) (string, error) {
+	if symbols == nil {
+		if current.epsilon {
+			return Epsilon, nil
+		}
+		if current.symbol == Epsilon || current.symbol == "0" {
+			return "", fmt.Errorf("symbol %q is reserved for epsilon without a symbol table", current.symbol)
+		}
+		return current.symbol, nil
+	}
+	if current.epsilon {
+		return "0", nil
+	}
+	id, exists := symbols.ID(current.symbol)
+	if !exists {
+		return "", fmt.Errorf("symbol %q not in symbol table", current.symbol)
+	}
+	if id == 0 {
+		return "", fmt.Errorf("symbol %q has the epsilon id 0", current.symbol)
+	}
+	return strconv.Itoa(id), nil
+}
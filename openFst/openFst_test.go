@@ -0,0 +1,112 @@
+package openfst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ChristopherCamara/finiteAutomata/dfa"
+	"github.com/ChristopherCamara/finiteAutomata/nfa"
+)
+
+//numericDFA is over the symbols "a" and "1", whose name looks like the id of "a" in a symbol table
+func numericDFA() *dfa.DFA {
+	DFA := dfa.New()
+	DFA.Alphabet = []string{"a", "1"}
+	startState := DFA.AddState(true, false)
+	middleState := DFA.AddState(false, false)
+	acceptState := DFA.AddState(false, true)
+	DFA.AddTransition(startState, "a", middleState)
+	DFA.AddTransition(startState, "1", acceptState)
+	DFA.AddTransition(middleState, "1", acceptState)
+	DFA.AddTransition(acceptState, "a", acceptState)
+	return DFA
+}
+
+func TestDFARoundTrip(t *testing.T) {
+	for name, opts := range map[string]Options{
+		"symbol table":          {Symbols: NewSymbolTable([]string{"a", "1"})},
+		"symbol table acceptor": {Symbols: NewSymbolTable([]string{"a", "1"}), Acceptor: true},
+		"literal":               {},
+		"literal acceptor":      {Acceptor: true},
+	} {
+		var text bytes.Buffer
+		if err := WriteDFA(&text, numericDFA(), opts); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		DFA, err := ReadDFA(&text, opts)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !dfa.Isomorphic(DFA, numericDFA()) {
+			t.Errorf("%s: DFA changed on a round trip", name)
+		}
+	}
+}
+
+func TestNFARoundTrip(t *testing.T) {
+	NFA := nfa.New()
+	NFA.Alphabet = []string{"a", "1"}
+	startState := NFA.AddState(true, false)
+	acceptState := NFA.AddState(false, true)
+	NFA.AddEpsilonTransition(startState, acceptState)
+	NFA.AddTransition(startState, "1", startState)
+	NFA.AddTransition(startState, "a", acceptState)
+	for name, opts := range map[string]Options{
+		"symbol table": {Symbols: NewSymbolTable(NFA.Alphabet)},
+		"literal":      {},
+	} {
+		var text bytes.Buffer
+		if err := WriteNFA(&text, NFA, opts); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		readNFA, err := ReadNFA(&text, opts)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(readNFA.EpsilonTransitions[0]) != 1 {
+			t.Errorf("%s: expected one epsilon transition, got %v", name, readNFA.EpsilonTransitions)
+		}
+		if !dfa.Isomorphic(dfa.FromNFA(readNFA), dfa.FromNFA(NFA)) {
+			t.Errorf("%s: NFA changed on a round trip", name)
+		}
+	}
+}
+
+func TestReadLabels(t *testing.T) {
+	//without a symbol table 0 is epsilon like in fstprint output
+	NFA, err := ReadNFA(strings.NewReader("0\t1\t0\n1\t2\ta\n2\n"), Options{Acceptor: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(NFA.EpsilonTransitions[0]) != 1 || len(NFA.Alphabet) != 1 {
+		t.Errorf("expected 0 to be read as epsilon, got alphabet %v", NFA.Alphabet)
+	}
+	//with a symbol table labels are ids only, even when a symbol has the same name
+	table := NewSymbolTable([]string{"2", "1"})
+	DFA, err := ReadDFA(strings.NewReader("0\t1\t1\n1\n"), Options{Symbols: table, Acceptor: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, exists := DFA.Transitions[0]["2"]; !exists {
+		t.Errorf("expected label 1 to be read as symbol 2, got %v", DFA.Transitions[0])
+	}
+	if _, err := ReadDFA(strings.NewReader("0\t1\ta\n1\n"), Options{Symbols: table, Acceptor: true}); err == nil {
+		t.Error("expected an error for a label that is not an id")
+	}
+	if _, err := ReadDFA(strings.NewReader("0\t1\t7\n1\n"), Options{Symbols: table, Acceptor: true}); err == nil {
+		t.Error("expected an error for an id missing from the symbol table")
+	}
+}
+
+func TestWriteReservedSymbols(t *testing.T) {
+	for _, symbol := range []string{"0", Epsilon} {
+		DFA := dfa.New()
+		state := DFA.AddState(true, true)
+		DFA.Alphabet = []string{symbol}
+		DFA.AddTransition(state, symbol, state)
+		if err := WriteDFA(new(bytes.Buffer), DFA, Options{}); err == nil {
+			t.Errorf("expected an error writing symbol %q without a symbol table", symbol)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package openfst
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//Epsilon is the label reserved for epsilon transitions, it always has ID 0 in a SymbolTable
+const Epsilon = "<eps>"
+
+//SymbolTable maps symbols to the integer labels of an OpenFst symbol table file
+type SymbolTable struct {
+	ids     map[string]int
+	symbols map[int]string
+	nextID  int
+}
+
+//NewSymbolTable returns a *SymbolTable with Epsilon as 0 followed by alphabet numbered from 1
+func NewSymbolTable(alphabet []string) *SymbolTable {
+	table := &SymbolTable{ids: make(map[string]int), symbols: make(map[int]string)}
+	table.set(Epsilon, 0)
+	for _, symbol := range alphabet {
+		table.Add(symbol)
+	}
+	return table
+}
+
+//ReadSymbolTable reads an OpenFst text symbol table, one "symbol id" pair per line
+func ReadSymbolTable(r io.Reader) (*SymbolTable, error) {
+	table := &SymbolTable{ids: make(map[string]int), symbols: make(map[int]string)}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("symbol table line %d: expected symbol and id, found %d fields", line, len(fields))
+		}
+		id, err := strconv.Atoi(fields[1])
+		if err != nil || id < 0 {
+			return nil, fmt.Errorf("symbol table line %d: invalid id %q", line, fields[1])
+		}
+		if _, exists := table.ids[fields[0]]; exists {
+			return nil, fmt.Errorf("symbol table line %d: duplicate symbol %q", line, fields[0])
+		}
+		if _, exists := table.symbols[id]; exists {
+			return nil, fmt.Errorf("symbol table line %d: duplicate id %d", line, id)
+		}
+		table.set(fields[0], id)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+func (table *SymbolTable) set(symbol string, id int) {
+	table.ids[symbol] = id
+	table.symbols[id] = symbol
+	if id >= table.nextID {
+		table.nextID = id + 1
+	}
+}
+
+//Add symbol to the table if it is missing, returns its ID
+func (table *SymbolTable) Add(symbol string) int {
+	if id, exists := table.ids[symbol]; exists {
+		return id
+	}
+	id := table.nextID
+	table.set(symbol, id)
+	return id
+}
+
+//ID of symbol, false if the table does not know it
+func (table *SymbolTable) ID(symbol string) (int, bool) {
+	id, exists := table.ids[symbol]
+	return id, exists
+}
+
+//Symbol with the given ID, false if the table does not know it
+func (table *SymbolTable) Symbol(id int) (string, bool) {
+	symbol, exists := table.symbols[id]
+	return symbol, exists
+}
+
+//Write the table in OpenFst text format ordered by ID
+func (table *SymbolTable) Write(w io.Writer) error {
+	ids := make([]int, 0, len(table.symbols))
+	for id := range table.symbols {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	writer := bufio.NewWriter(w)
+	for _, id := range ids {
+		fmt.Fprintf(writer, "%s\t%d\n", table.symbols[id], id)
+	}
+	return writer.Flush()
+}
@@ -0,0 +1,57 @@
+package patternset
+
+import (
+	"fmt"
+
+	"github.com/ChristopherCamara/finiteAutomata/dfa"
+	"github.com/ChristopherCamara/finiteAutomata/nfa"
+	regexparser "github.com/ChristopherCamara/finiteAutomata/regexParser"
+)
+
+//PatternSet matches many regular expressions at once
+//
+//Every pattern is identified by its index in Patterns, the patterns are
+//compiled into one DFA whose accept states are labeled with the matching IDs
+type PatternSet struct {
+	Patterns []string
+	DFA      *dfa.DFA
+}
+
+//New returns ready to use *PatternSet for the given patterns, or an error naming the first pattern that does not parse
+//
+//Compiling the combined DFA is the expensive part, a set should be built once
+//and reused for every input
+func New(patterns []string) (*PatternSet, error) {
+	parser := new(regexparser.RegexParser)
+	nfas := make([]*nfa.NFA, 0, len(patterns))
+	for id, pattern := range patterns {
+		patternNFA, err := parser.Parse(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("pattern %d: %v", id, err)
+		}
+		patternNFA.LabelAcceptStates(id)
+		nfas = append(nfas, patternNFA)
+	}
+	combinedNFA := nfa.Combine(nfas...)
+	combinedNFA.Alphabet = parser.Alphabet
+	combinedDFA := dfa.FromNFA(combinedNFA)
+	combinedDFA.Minimize()
+	return &PatternSet{Patterns: patterns, DFA: combinedDFA}, nil
+}
+
+//Match returns the ID of every pattern that accepts the whole input, in increasing order
+func (set *PatternSet) Match(input string) []int {
+	runner := set.DFA.NewRunner()
+	for position := 0; position < len(input); {
+		symbol, size := dfa.DecodeSymbol(input[position:])
+		position += size
+		if !runner.Step(symbol) {
+			return []int{}
+		}
+	}
+	matched := runner.AcceptLabels()
+	if matched == nil {
+		return []int{}
+	}
+	return append([]int(nil), matched...)
+}
@@ -0,0 +1,83 @@
+package patternset
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	set, err := New([]string{"ab*", "abb", "(a|b)*c", "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for input, expected := range map[string][]int{
+		"a":    {0},
+		"abb":  {0, 1},
+		"abbb": {0},
+		"abc":  {2},
+		"c":    {2, 3},
+		"":     {},
+		"d":    {},
+		"ba":   {},
+	} {
+		if matched := set.Match(input); !reflect.DeepEqual(matched, expected) {
+			t.Errorf("%q: expected %v, got %v", input, expected, matched)
+		}
+	}
+}
+
+func TestNewInvalidPattern(t *testing.T) {
+	for _, pattern := range []string{"a|", "(ab", "ab)", "*a"} {
+		if _, err := New([]string{"ab", pattern}); err == nil {
+			t.Errorf("expected an error for pattern %q", pattern)
+		}
+	}
+}
+
+//keywordPatterns are n random words, some with an alternation inside a closure
+func keywordPatterns(n int) []string {
+	random := rand.New(rand.NewSource(1))
+	patterns := make([]string, n)
+	for i := range patterns {
+		word := ""
+		for j := 0; j < 4+random.Intn(6); j++ {
+			word += string(rune('a' + random.Intn(26)))
+		}
+		if i%2 == 0 {
+			patterns[i] = word
+		} else {
+			patterns[i] = word[:2] + "(" + word[2:3] + "|" + word[3:4] + ")*" + word[4:]
+		}
+	}
+	return patterns
+}
+
+func TestManyPatterns(t *testing.T) {
+	patterns := keywordPatterns(200)
+	set, err := New(patterns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, pattern := range patterns {
+		if id%2 != 0 {
+			continue
+		}
+		found := false
+		for _, matchedID := range set.Match(pattern) {
+			found = found || matchedID == id
+		}
+		if !found {
+			t.Errorf("%q: expected pattern %d to match", pattern, id)
+		}
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	patterns := keywordPatterns(500)
+	for i := 0; i < b.N; i++ {
+		if _, err := New(patterns); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
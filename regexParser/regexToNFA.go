@@ -1,6 +1,8 @@
 package regexparser
 
 import (
+	"fmt"
+
 	"github.com/ChristopherCamara/finiteAutomata/internal/stringArray"
 	"github.com/ChristopherCamara/finiteAutomata/nfa"
 )
@@ -63,3 +65,26 @@ func (p *RegexParser) ParseToNFA(regex string) *nfa.NFA {
 	newNFA.Alphabet = p.Alphabet
 	return newNFA
 }
+
+//Parse the given regular expression like ParseToNFA, returning an error instead of panicking
+//
+//The whole regex must be consumed, an unmatched ")" is an error too. On error
+//the Alphabet of the parser is left as it was
+func (p *RegexParser) Parse(regex string) (newNFA *nfa.NFA, err error) {
+	alphabetSize := len(p.Alphabet)
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			p.Alphabet = p.Alphabet[:alphabetSize]
+			message := fmt.Sprint(recovered)
+			if p.position >= len(regex) {
+				message = "unexpected end of regex"
+			}
+			newNFA, err = nil, fmt.Errorf("invalid regex %q at position %d: %s", regex, p.position, message)
+		}
+	}()
+	newNFA = p.ParseToNFA(regex)
+	if p.hasMoreChars() {
+		panic("Ran into unexpected character!")
+	}
+	return newNFA, nil
+}
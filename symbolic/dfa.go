@@ -0,0 +1,354 @@
+package symbolic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+)
+
+//DFA deterministic finite automata whose transitions are labeled with rune sets
+//
+//The sets on the transitions leaving a state never overlap, a rune that is in
+//none of them has no transition
+type DFA struct {
+	nextState    int
+	States       []int
+	StartStates  []int
+	AcceptStates []int
+	Transitions  map[int][]Transition
+}
+
+//NewDFA returns ready to use *DFA
+func NewDFA() *DFA {
+	newDFA := new(DFA)
+	newDFA.nextState = 0
+	newDFA.States = make([]int, 0)
+	newDFA.StartStates = make([]int, 0)
+	newDFA.AcceptStates = make([]int, 0)
+	newDFA.Transitions = make(map[int][]Transition, 0)
+	return newDFA
+}
+
+//AddState to a DFA
+func (dfa *DFA) AddState(isStart, isAccept bool) int {
+	index := dfa.nextState
+	dfa.States = append(dfa.States, index)
+	dfa.Transitions[index] = make([]Transition, 0)
+	if isStart {
+		dfa.StartStates = append(dfa.StartStates, index)
+	}
+	if isAccept {
+		dfa.AcceptStates = append(dfa.AcceptStates, index)
+	}
+	dfa.nextState++
+	return index
+}
+
+//AddTransition from sourceState to targetState on any rune of set
+//
+//The set must not overlap the sets already leaving sourceState, empty sets are ignored
+func (dfa *DFA) AddTransition(sourceState int, set Set, targetState int) {
+	if set.IsEmpty() {
+		return
+	}
+	dfa.Transitions[sourceState] = append(dfa.Transitions[sourceState], Transition{Set: set, To: targetState})
+}
+
+//Step returns the state reached from state on r, -1 if there is no transition
+func (dfa *DFA) Step(state int, r rune) int {
+	for _, transition := range dfa.Transitions[state] {
+		if transition.Set.Contains(r) {
+			return transition.To
+		}
+	}
+	return -1
+}
+
+//Accepts reports whether input, read one rune at a time, is in the language of a DFA
+func (dfa *DFA) Accepts(input string) bool {
+	if len(dfa.StartStates) == 0 {
+		return false
+	}
+	state := dfa.StartStates[0]
+	for _, current := range input {
+		state = dfa.Step(state, current)
+		if state == -1 {
+			return false
+		}
+	}
+	return intArray.IndexOf(state, dfa.AcceptStates) != -1
+}
+
+//minterms splits the union of sets into the coarsest disjoint sets that each
+//lie either completely inside or completely outside every given set
+func minterms(sets []Set) []Set {
+	terms := make([]Set, 0)
+	for _, set := range sets {
+		refined := make([]Set, 0, len(terms)+1)
+		rest := set
+		for _, term := range terms {
+			if inside := term.Intersect(set); !inside.IsEmpty() {
+				refined = append(refined, inside)
+			}
+			if outside := term.Difference(set); !outside.IsEmpty() {
+				refined = append(refined, outside)
+			}
+			rest = rest.Difference(term)
+		}
+		if !rest.IsEmpty() {
+			refined = append(refined, rest)
+		}
+		terms = refined
+	}
+	return terms
+}
+
+func subsetKey(states []int) string {
+	var key strings.Builder
+	for index, state := range states {
+		if index != 0 {
+			key.WriteByte(',')
+		}
+		key.WriteString(strconv.Itoa(state))
+	}
+	return key.String()
+}
+
+//FromNFA create a DFA from a NFA
+//
+//The rune sets leaving every subset of NFA states are split into minterms, so
+//each minterm leads to exactly one subset. Minterms leading to the same subset
+//are joined into one transition
+func FromNFA(NFA *NFA) *DFA {
+	dfa := NewDFA()
+	start := NFA.epsilonClosure(NFA.StartStates)
+	subsets := [][]int{start}
+	subsetIndexes := map[string]int{subsetKey(start): 0}
+	for i := 0; i < len(subsets); i++ {
+		isAccept := false
+		for _, state := range subsets[i] {
+			if intArray.IndexOf(state, NFA.AcceptStates) != -1 {
+				isAccept = true
+				break
+			}
+		}
+		dfa.AddState(i == 0, isAccept)
+		sets := make([]Set, 0)
+		for _, state := range subsets[i] {
+			for _, transition := range NFA.Transitions[state] {
+				sets = append(sets, transition.Set)
+			}
+		}
+		targets := make([]int, 0)
+		targetSets := make(map[int]Set)
+		for _, term := range minterms(sets) {
+			moveStates := make([]int, 0)
+			for _, state := range subsets[i] {
+				for _, transition := range NFA.Transitions[state] {
+					//a minterm is either inside a set or disjoint from it
+					if transition.Set.Contains(term[0].Lo) && intArray.IndexOf(transition.To, moveStates) == -1 {
+						moveStates = append(moveStates, transition.To)
+					}
+				}
+			}
+			closure := NFA.epsilonClosure(moveStates)
+			index, exists := subsetIndexes[subsetKey(closure)]
+			if !exists {
+				index = len(subsets)
+				subsets = append(subsets, closure)
+				subsetIndexes[subsetKey(closure)] = index
+			}
+			if _, exists := targetSets[index]; !exists {
+				targets = append(targets, index)
+			}
+			targetSets[index] = targetSets[index].Union(term)
+		}
+		for _, target := range targets {
+			dfa.Transitions[i] = append(dfa.Transitions[i], Transition{Set: targetSets[target], To: target})
+		}
+	}
+	return dfa
+}
+
+//Minimize a DFA, transform a DFA to the DFA with minimal states
+//
+//States that cannot be reached or can never accept are dropped first, the
+//remaining states are refined over the minterms of every transition set
+func (dfa *DFA) Minimize() {
+	dfa.trim()
+	minDFA := NewDFA()
+	if len(dfa.States) == 0 {
+		*dfa = *minDFA
+		return
+	}
+	sets := make([]Set, 0)
+	for _, state := range dfa.States {
+		for _, transition := range dfa.Transitions[state] {
+			sets = append(sets, transition.Set)
+		}
+	}
+	terms := minterms(sets)
+	partitionOf := make(map[int]int)
+	for _, state := range dfa.States {
+		if intArray.IndexOf(state, dfa.AcceptStates) != -1 {
+			partitionOf[state] = 1
+		}
+	}
+	for numPartitions := 0; ; {
+		//states stay together while they agree on the partition reached by every minterm
+		signatures := make(map[string]int)
+		nextPartitionOf := make(map[int]int)
+		for _, state := range dfa.States {
+			var signature strings.Builder
+			signature.WriteString(strconv.Itoa(partitionOf[state]))
+			for _, term := range terms {
+				signature.WriteByte(',')
+				targetState := dfa.Step(state, term[0].Lo)
+				if targetState == -1 {
+					signature.WriteByte('-')
+				} else {
+					signature.WriteString(strconv.Itoa(partitionOf[targetState]))
+				}
+			}
+			partition, exists := signatures[signature.String()]
+			if !exists {
+				partition = len(signatures)
+				signatures[signature.String()] = partition
+			}
+			nextPartitionOf[state] = partition
+		}
+		partitionOf = nextPartitionOf
+		if len(signatures) == numPartitions {
+			break
+		}
+		numPartitions = len(signatures)
+	}
+	//number the minimal states in the order their partitions are first reached
+	minStates := make(map[int]int)
+	representatives := make([]int, 0)
+	for _, state := range dfa.States {
+		if _, exists := minStates[partitionOf[state]]; !exists {
+			minStates[partitionOf[state]] = minDFA.AddState(partitionOf[state] == partitionOf[dfa.StartStates[0]], intArray.IndexOf(state, dfa.AcceptStates) != -1)
+			representatives = append(representatives, state)
+		}
+	}
+	for _, state := range representatives {
+		targets := make([]int, 0)
+		targetSets := make(map[int]Set)
+		for _, transition := range dfa.Transitions[state] {
+			target := minStates[partitionOf[transition.To]]
+			if _, exists := targetSets[target]; !exists {
+				targets = append(targets, target)
+			}
+			targetSets[target] = targetSets[target].Union(transition.Set)
+		}
+		for _, target := range targets {
+			minDFA.AddTransition(minStates[partitionOf[state]], targetSets[target], target)
+		}
+	}
+	*dfa = *minDFA
+}
+
+//trim drops the states that cannot be reached from the start state or can never reach an accept state
+func (dfa *DFA) trim() {
+	reachable := make(map[int]bool)
+	if len(dfa.StartStates) != 0 {
+		queue := []int{dfa.StartStates[0]}
+		reachable[dfa.StartStates[0]] = true
+		for len(queue) != 0 {
+			state := queue[0]
+			queue = queue[1:]
+			for _, transition := range dfa.Transitions[state] {
+				if !reachable[transition.To] {
+					reachable[transition.To] = true
+					queue = append(queue, transition.To)
+				}
+			}
+		}
+	}
+	live := make(map[int]bool)
+	for _, acceptState := range dfa.AcceptStates {
+		live[acceptState] = true
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, state := range dfa.States {
+			if live[state] {
+				continue
+			}
+			for _, transition := range dfa.Transitions[state] {
+				if live[transition.To] {
+					live[state] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	trimDFA := NewDFA()
+	stateMappings := make(map[int]int)
+	for _, state := range dfa.States {
+		if reachable[state] && live[state] {
+			stateMappings[state] = trimDFA.AddState(intArray.IndexOf(state, dfa.StartStates) != -1, intArray.IndexOf(state, dfa.AcceptStates) != -1)
+		}
+	}
+	for _, state := range dfa.States {
+		if _, exists := stateMappings[state]; !exists {
+			continue
+		}
+		for _, transition := range dfa.Transitions[state] {
+			if targetState, exists := stateMappings[transition.To]; exists {
+				trimDFA.AddTransition(stateMappings[state], transition.Set, targetState)
+			}
+		}
+	}
+	*dfa = *trimDFA
+}
+
+//Intersect two DFAs with the product construction, the result accepts the words both accept
+func Intersect(first, second *DFA) *DFA {
+	product := NewDFA()
+	if len(first.StartStates) == 0 || len(second.StartStates) == 0 {
+		return product
+	}
+	pairs := [][2]int{{first.StartStates[0], second.StartStates[0]}}
+	pairIndexes := map[[2]int]int{pairs[0]: 0}
+	for i := 0; i < len(pairs); i++ {
+		pair := pairs[i]
+		product.AddState(i == 0, intArray.IndexOf(pair[0], first.AcceptStates) != -1 && intArray.IndexOf(pair[1], second.AcceptStates) != -1)
+		for _, firstTransition := range first.Transitions[pair[0]] {
+			for _, secondTransition := range second.Transitions[pair[1]] {
+				set := firstTransition.Set.Intersect(secondTransition.Set)
+				if set.IsEmpty() {
+					continue
+				}
+				target := [2]int{firstTransition.To, secondTransition.To}
+				index, exists := pairIndexes[target]
+				if !exists {
+					index = len(pairs)
+					pairs = append(pairs, target)
+					pairIndexes[target] = index
+				}
+				product.Transitions[i] = append(product.Transitions[i], Transition{Set: set, To: index})
+			}
+		}
+	}
+	return product
+}
+
+//Print out DFA information
+func (dfa *DFA) Print() {
+	fmt.Println("~~~Symbolic DFA~~~")
+	fmt.Print("start states: ")
+	intArray.Print(dfa.StartStates)
+	for _, state := range dfa.States {
+		fmt.Printf("state %d:\n", state)
+		for _, transition := range dfa.Transitions[state] {
+			fmt.Printf("\t%s -> %d\n", transition.Set, transition.To)
+		}
+	}
+	fmt.Print("accept states: ")
+	intArray.Print(dfa.AcceptStates)
+}
@@ -0,0 +1,178 @@
+package symbolic
+
+import (
+	"testing"
+	"unicode"
+)
+
+var hexSet = NewSet(Range{'0', '9'}, Range{'a', 'f'})
+
+//letterOrHexNFA accepts words of letters or words of hex digits, the two overlap on a-f
+func letterOrHexNFA() *NFA {
+	NFA := NewNFA()
+	start := NFA.AddState(true, false)
+	letters := NFA.AddState(false, true)
+	hex := NFA.AddState(false, true)
+	letterSet := FromRangeTable(unicode.Letter)
+	NFA.AddTransition(start, letterSet, letters)
+	NFA.AddTransition(letters, letterSet, letters)
+	NFA.AddTransition(start, hexSet, hex)
+	NFA.AddTransition(hex, hexSet, hex)
+	return NFA
+}
+
+//hexDFA accepts words of hex digits with more states than needed, one unreachable and one that never accepts
+func hexDFA() *DFA {
+	hex := NewDFA()
+	start := hex.AddState(true, false)
+	odd := hex.AddState(false, true)
+	even := hex.AddState(false, true)
+	dead := hex.AddState(false, false)
+	unreachable := hex.AddState(false, true)
+	hex.AddTransition(start, hexSet, odd)
+	hex.AddTransition(odd, hexSet, even)
+	hex.AddTransition(even, hexSet, odd)
+	hex.AddTransition(even, Rune('x'), dead)
+	hex.AddTransition(dead, All(), dead)
+	hex.AddTransition(unreachable, All(), start)
+	return hex
+}
+
+//words of up to three runes over runes chosen to fall in every part of the sets above
+func words() []string {
+	runes := []rune{'a', 'f', 'g', 'x', '0', '9', '@', 'é', '中', '😀'}
+	words := []string{""}
+	for length, start := 0, 0; length < 3; length++ {
+		end := len(words)
+		for _, word := range words[start:end] {
+			for _, r := range runes {
+				words = append(words, word+string(r))
+			}
+		}
+		start = end
+	}
+	return words
+}
+
+func checkDisjoint(t *testing.T, dfa *DFA) {
+	t.Helper()
+	for _, state := range dfa.States {
+		transitions := dfa.Transitions[state]
+		for i := range transitions {
+			for j := i + 1; j < len(transitions); j++ {
+				if overlap := transitions[i].Set.Intersect(transitions[j].Set); !overlap.IsEmpty() {
+					t.Errorf("state %d: transitions overlap on %s", state, overlap)
+				}
+			}
+		}
+	}
+}
+
+func TestFromNFA(t *testing.T) {
+	NFA := letterOrHexNFA()
+	dfa := FromNFA(NFA)
+	checkDisjoint(t, dfa)
+	for _, word := range words() {
+		if dfa.Accepts(word) != NFA.Accepts(word) {
+			t.Errorf("%q: expected %t, got %t", word, NFA.Accepts(word), dfa.Accepts(word))
+		}
+	}
+	//start, letters and hex digits, letters only and hex digits only
+	if len(dfa.States) != 4 {
+		t.Errorf("expected 4 states, got %d", len(dfa.States))
+	}
+}
+
+func TestMinimize(t *testing.T) {
+	for name, current := range map[string]struct {
+		dfa    *DFA
+		states int
+	}{
+		"hex":            {hexDFA(), 2},
+		"letters or hex": {FromNFA(letterOrHexNFA()), 4},
+		"empty language": {FromNFA(NewNFA()), 0},
+		"only never accepting": {func() *DFA {
+			dfa := NewDFA()
+			start := dfa.AddState(true, false)
+			dfa.AddTransition(start, All(), start)
+			return dfa
+		}(), 0},
+	} {
+		original := words()
+		expected := make([]bool, len(original))
+		for i, word := range original {
+			expected[i] = current.dfa.Accepts(word)
+		}
+		current.dfa.Minimize()
+		if len(current.dfa.States) != current.states {
+			t.Errorf("%s: expected %d states, got %d", name, current.states, len(current.dfa.States))
+		}
+		checkDisjoint(t, current.dfa)
+		for i, word := range original {
+			if current.dfa.Accepts(word) != expected[i] {
+				t.Errorf("%s %q: expected %t after minimizing", name, word, expected[i])
+			}
+		}
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	letterOrHex := FromNFA(letterOrHexNFA())
+	letters := NewDFA()
+	start := letters.AddState(true, false)
+	accept := letters.AddState(false, true)
+	letters.AddTransition(start, FromRangeTable(unicode.Letter), accept)
+	letters.AddTransition(accept, FromRangeTable(unicode.Letter), accept)
+	digits := NewDFA()
+	start = digits.AddState(true, false)
+	accept = digits.AddState(false, true)
+	digits.AddTransition(start, NewSet(Range{'0', '9'}), accept)
+	digits.AddTransition(accept, NewSet(Range{'0', '9'}), accept)
+	for name, pair := range map[string][2]*DFA{
+		"hex":            {letterOrHex, hexDFA()},
+		"letters":        {letters, letterOrHex},
+		"minimized hex":  {letters, func() *DFA { hex := hexDFA(); hex.Minimize(); return hex }()},
+		"empty":          {letters, digits},
+		"no start state": {letters, NewDFA()},
+	} {
+		product := Intersect(pair[0], pair[1])
+		checkDisjoint(t, product)
+		for _, word := range words() {
+			expected := pair[0].Accepts(word) && pair[1].Accepts(word)
+			if product.Accepts(word) != expected {
+				t.Errorf("%s %q: expected %t", name, word, expected)
+			}
+		}
+	}
+	if product := Intersect(letters, digits); len(product.AcceptStates) != 0 {
+		t.Errorf("letters and digits: expected no accept states, got %v", product.AcceptStates)
+	}
+}
+
+func TestCompileUTF8(t *testing.T) {
+	runeDFA := FromNFA(letterOrHexNFA())
+	byteDFA := runeDFA.CompileUTF8()
+	for _, word := range words() {
+		if byteDFA.AcceptsBytes([]byte(word)) != runeDFA.Accepts(word) {
+			t.Errorf("%q: expected %t", word, runeDFA.Accepts(word))
+		}
+	}
+	anything := NewDFA()
+	state := anything.AddState(true, true)
+	anything.AddTransition(state, All(), state)
+	byteDFA = anything.CompileUTF8()
+	for input, expected := range map[string]bool{
+		"":           true,
+		"aé中😀":       true,
+		"\U0010FFFF": true,
+		"\xff":       false,
+		"a\xc3":      false,
+		"\xc0\x80":   false,
+		//an encoded surrogate is not valid UTF-8
+		"\xed\xa0\x80": false,
+	} {
+		if byteDFA.AcceptsBytes([]byte(input)) != expected {
+			t.Errorf("%q: expected %t", input, expected)
+		}
+	}
+}
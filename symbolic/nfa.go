@@ -0,0 +1,157 @@
+package symbolic
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+	"github.com/ChristopherCamara/finiteAutomata/nfa"
+)
+
+//Transition to a state on any rune of Set
+type Transition struct {
+	Set Set
+	To  int
+}
+
+//NFA non-deterministic finite automata whose transitions are labeled with rune sets
+type NFA struct {
+	nextState          int
+	States             []int
+	StartStates        []int
+	AcceptStates       []int
+	Transitions        map[int][]Transition
+	EpsilonTransitions map[int][]int
+}
+
+//NewNFA returns ready to use *NFA
+func NewNFA() *NFA {
+	newNFA := new(NFA)
+	newNFA.nextState = 0
+	newNFA.States = make([]int, 0)
+	newNFA.StartStates = make([]int, 0)
+	newNFA.AcceptStates = make([]int, 0)
+	newNFA.Transitions = make(map[int][]Transition, 0)
+	newNFA.EpsilonTransitions = make(map[int][]int, 0)
+	return newNFA
+}
+
+//AddState to a NFA
+func (nfa *NFA) AddState(isStart, isAccept bool) int {
+	index := nfa.nextState
+	nfa.States = append(nfa.States, index)
+	nfa.Transitions[index] = make([]Transition, 0)
+	nfa.EpsilonTransitions[index] = make([]int, 0)
+	if isStart {
+		nfa.StartStates = append(nfa.StartStates, index)
+	}
+	if isAccept {
+		nfa.AcceptStates = append(nfa.AcceptStates, index)
+	}
+	nfa.nextState++
+	return index
+}
+
+//AddTransition from sourceState to targetState on any rune of set, empty sets are ignored
+func (nfa *NFA) AddTransition(sourceState int, set Set, targetState int) {
+	if set.IsEmpty() {
+		return
+	}
+	nfa.Transitions[sourceState] = append(nfa.Transitions[sourceState], Transition{Set: set, To: targetState})
+}
+
+//AddEpsilonTransition from sourceState to targetState
+func (nfa *NFA) AddEpsilonTransition(sourceState, targetState int) {
+	nfa.EpsilonTransitions[sourceState] = append(nfa.EpsilonTransitions[sourceState], targetState)
+}
+
+//LiftNFA turns a NFA over single rune symbols into a NFA over rune sets
+func LiftNFA(NFA *nfa.NFA) (*NFA, error) {
+	lifted := NewNFA()
+	stateMappings := make(map[int]int)
+	for _, state := range NFA.States {
+		stateMappings[state] = lifted.AddState(intArray.IndexOf(state, NFA.StartStates) != -1, intArray.IndexOf(state, NFA.AcceptStates) != -1)
+	}
+	for _, state := range NFA.States {
+		for _, transitionState := range NFA.EpsilonTransitions[state] {
+			lifted.AddEpsilonTransition(stateMappings[state], stateMappings[transitionState])
+		}
+		symbols := make([]string, 0, len(NFA.Transitions[state]))
+		for symbol := range NFA.Transitions[state] {
+			symbols = append(symbols, symbol)
+		}
+		sort.Strings(symbols)
+		for _, symbol := range symbols {
+			if utf8.RuneCountInString(symbol) != 1 {
+				return nil, fmt.Errorf("symbol %q of state %d is not a single rune", symbol, state)
+			}
+			current, _ := utf8.DecodeRuneInString(symbol)
+			for _, transitionState := range NFA.Transitions[state][symbol] {
+				lifted.AddTransition(stateMappings[state], Rune(current), stateMappings[transitionState])
+			}
+		}
+	}
+	return lifted, nil
+}
+
+//epsilonClosure of a set of states, the result is sorted
+func (nfa *NFA) epsilonClosure(states []int) []int {
+	closure := make([]int, 0, len(states))
+	stack := append([]int(nil), states...)
+	for len(stack) != 0 {
+		state := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if intArray.IndexOf(state, closure) != -1 {
+			continue
+		}
+		closure = append(closure, state)
+		stack = append(stack, nfa.EpsilonTransitions[state]...)
+	}
+	sort.Ints(closure)
+	return closure
+}
+
+//Accepts reports whether input, read one rune at a time, is in the language of a NFA
+func (nfa *NFA) Accepts(input string) bool {
+	states := nfa.epsilonClosure(nfa.StartStates)
+	for _, current := range input {
+		if len(states) == 0 {
+			return false
+		}
+		nextStates := make([]int, 0)
+		for _, state := range states {
+			for _, transition := range nfa.Transitions[state] {
+				if transition.Set.Contains(current) {
+					nextStates = append(nextStates, transition.To)
+				}
+			}
+		}
+		states = nfa.epsilonClosure(nextStates)
+	}
+	for _, state := range states {
+		if intArray.IndexOf(state, nfa.AcceptStates) != -1 {
+			return true
+		}
+	}
+	return false
+}
+
+//Print out NFA information
+func (nfa *NFA) Print() {
+	fmt.Println("~~~Symbolic NFA~~~")
+	fmt.Print("start states: ")
+	intArray.Print(nfa.StartStates)
+	for _, state := range nfa.States {
+		fmt.Printf("state %d:\n", state)
+		for _, transition := range nfa.Transitions[state] {
+			fmt.Printf("\t%s -> %d\n", transition.Set, transition.To)
+		}
+		if len(nfa.EpsilonTransitions[state]) != 0 {
+			fmt.Print("\t(empty) -> ")
+			intArray.Print(nfa.EpsilonTransitions[state])
+		}
+	}
+	fmt.Print("accept states: ")
+	intArray.Print(nfa.AcceptStates)
+}
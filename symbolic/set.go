@@ -0,0 +1,189 @@
+package symbolic
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+//Range of runes from Lo to Hi, both included
+type Range struct {
+	Lo rune
+	Hi rune
+}
+
+//Set of runes stored as sorted ranges that neither overlap nor touch
+type Set []Range
+
+//NewSet returns the normalized set covering every given range, ranges with Lo > Hi are ignored
+func NewSet(ranges ...Range) Set {
+	set := make(Set, 0, len(ranges))
+	for _, current := range ranges {
+		if current.Lo <= current.Hi {
+			set = append(set, current)
+		}
+	}
+	return set.normalize()
+}
+
+//Rune returns the set holding only r
+func Rune(r rune) Set {
+	return Set{{r, r}}
+}
+
+//All returns the set of every rune
+func All() Set {
+	return Set{{0, unicode.MaxRune}}
+}
+
+//FromRangeTable returns the set of runes in a unicode.RangeTable, such as unicode.Letter
+func FromRangeTable(table *unicode.RangeTable) Set {
+	set := make(Set, 0, len(table.R16)+len(table.R32))
+	for _, current := range table.R16 {
+		for r := rune(current.Lo); r <= rune(current.Hi); r += rune(current.Stride) {
+			if current.Stride == 1 {
+				set = append(set, Range{r, rune(current.Hi)})
+				break
+			}
+			set = append(set, Range{r, r})
+		}
+	}
+	for _, current := range table.R32 {
+		for r := rune(current.Lo); r <= rune(current.Hi); r += rune(current.Stride) {
+			if current.Stride == 1 {
+				set = append(set, Range{r, rune(current.Hi)})
+				break
+			}
+			set = append(set, Range{r, r})
+		}
+	}
+	return set.normalize()
+}
+
+//normalize sorts the ranges and merges those that overlap or touch
+func (set Set) normalize() Set {
+	sort.Slice(set, func(i, j int) bool { return set[i].Lo < set[j].Lo })
+	normalized := make(Set, 0, len(set))
+	for _, current := range set {
+		last := len(normalized) - 1
+		if last >= 0 && current.Lo <= normalized[last].Hi+1 {
+			if current.Hi > normalized[last].Hi {
+				normalized[last].Hi = current.Hi
+			}
+			continue
+		}
+		normalized = append(normalized, current)
+	}
+	return normalized
+}
+
+//Union of two sets
+func (set Set) Union(other Set) Set {
+	union := make(Set, 0, len(set)+len(other))
+	union = append(union, set...)
+	union = append(union, other...)
+	return union.normalize()
+}
+
+//Intersect returns the runes in both sets
+func (set Set) Intersect(other Set) Set {
+	intersection := make(Set, 0)
+	for i, j := 0, 0; i < len(set) && j < len(other); {
+		lo, hi := set[i].Lo, set[i].Hi
+		if other[j].Lo > lo {
+			lo = other[j].Lo
+		}
+		if other[j].Hi < hi {
+			hi = other[j].Hi
+		}
+		if lo <= hi {
+			intersection = append(intersection, Range{lo, hi})
+		}
+		if set[i].Hi < other[j].Hi {
+			i++
+		} else {
+			j++
+		}
+	}
+	return intersection
+}
+
+//Complement returns every rune not in the set
+func (set Set) Complement() Set {
+	complement := make(Set, 0, len(set)+1)
+	next := rune(0)
+	for _, current := range set {
+		if current.Lo > next {
+			complement = append(complement, Range{next, current.Lo - 1})
+		}
+		next = current.Hi + 1
+	}
+	if next <= unicode.MaxRune {
+		complement = append(complement, Range{next, unicode.MaxRune})
+	}
+	return complement
+}
+
+//Difference returns the runes in the set that are not in other
+func (set Set) Difference(other Set) Set {
+	return set.Intersect(other.Complement())
+}
+
+//Contains reports whether r is in the set
+func (set Set) Contains(r rune) bool {
+	index := sort.Search(len(set), func(i int) bool { return set[i].Hi >= r })
+	return index < len(set) && set[index].Lo <= r
+}
+
+//IsEmpty reports whether the set holds no rune
+func (set Set) IsEmpty() bool {
+	return len(set) == 0
+}
+
+//Equal reports whether both sets hold the same runes
+func (set Set) Equal(other Set) bool {
+	if len(set) != len(other) {
+		return false
+	}
+	for i := range set {
+		if set[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
+//String formats the set like a regular expression character class, . for every rune
+func (set Set) String() string {
+	if set.Equal(All()) {
+		return "."
+	}
+	var builder strings.Builder
+	builder.WriteByte('[')
+	for _, current := range set {
+		writeClassRune(&builder, current.Lo)
+		if current.Hi > current.Lo {
+			if current.Hi > current.Lo+1 {
+				builder.WriteByte('-')
+			}
+			writeClassRune(&builder, current.Hi)
+		}
+	}
+	builder.WriteByte(']')
+	return builder.String()
+}
+
+func writeClassRune(builder *strings.Builder, r rune) {
+	switch {
+	case r == '\\' || r == ']' || r == '[' || r == '-' || r == '^':
+		builder.WriteByte('\\')
+		builder.WriteRune(r)
+	case unicode.IsPrint(r) && r != ' ':
+		builder.WriteRune(r)
+	default:
+		builder.WriteString(`\x{`)
+		builder.WriteString(strings.ToUpper(strconv.FormatInt(int64(r), 16)))
+		builder.WriteByte('}')
+	}
+}
@@ -0,0 +1,140 @@
+package symbolic
+
+import (
+	"unicode/utf8"
+
+	"github.com/ChristopherCamara/finiteAutomata/dfa"
+	"github.com/ChristopherCamara/finiteAutomata/internal/intArray"
+	"github.com/ChristopherCamara/finiteAutomata/nfa"
+)
+
+//byteRange of bytes from lo to hi, both included
+type byteRange struct {
+	lo byte
+	hi byte
+}
+
+//utf8Sequences splits a range of runes into sequences of byte ranges, the
+//encodings of the runes are exactly the byte strings matched by one of the
+//sequences. Surrogates have no UTF-8 encoding and are left out
+func utf8Sequences(current Range) [][]byteRange {
+	sequences := make([][]byteRange, 0)
+	stack := []Range{current}
+	for len(stack) != 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if current.Lo > current.Hi {
+			continue
+		}
+		if current.Lo <= 0xDFFF && current.Hi >= 0xD800 {
+			//cut out the surrogates, either side may end up empty
+			stack = append(stack, Range{0xE000, current.Hi}, Range{current.Lo, 0xD7FF})
+			continue
+		}
+		//runes of one range must all encode to the same number of bytes
+		split := false
+		for _, max := range []rune{0x7F, 0x7FF, 0xFFFF} {
+			if current.Lo <= max && max < current.Hi {
+				stack = append(stack, Range{max + 1, current.Hi}, Range{current.Lo, max})
+				split = true
+				break
+			}
+		}
+		if split {
+			continue
+		}
+		if current.Hi <= 0x7F {
+			sequences = append(sequences, []byteRange{{byte(current.Lo), byte(current.Hi)}})
+			continue
+		}
+		//every byte after a differing leading byte must span all continuation bytes
+		for i := uint(1); i < utf8.UTFMax; i++ {
+			mask := rune(1)<<(6*i) - 1
+			if current.Lo&^mask == current.Hi&^mask {
+				continue
+			}
+			if current.Lo&mask != 0 {
+				stack = append(stack, Range{(current.Lo | mask) + 1, current.Hi}, Range{current.Lo, current.Lo | mask})
+				split = true
+				break
+			}
+			if current.Hi&mask != mask {
+				stack = append(stack, Range{current.Hi &^ mask, current.Hi}, Range{current.Lo, current.Hi&^mask - 1})
+				split = true
+				break
+			}
+		}
+		if split {
+			continue
+		}
+		lo, hi := make([]byte, utf8.UTFMax), make([]byte, utf8.UTFMax)
+		size := utf8.EncodeRune(lo, current.Lo)
+		utf8.EncodeRune(hi, current.Hi)
+		sequence := make([]byteRange, size)
+		for i := 0; i < size; i++ {
+			sequence[i] = byteRange{lo[i], hi[i]}
+		}
+		sequences = append(sequences, sequence)
+	}
+	return sequences
+}
+
+//CompileUTF8 turns a DFA over rune sets into a minimal DFA over bytes
+//
+//Every range of a transition is split into sequences of UTF-8 byte ranges,
+//each becoming a chain of transitions over one byte strings. Chains share
+//their common suffixes and are determinised and minimized afterwards so
+//encodings share their prefixes too
+func (symbolicDFA *DFA) CompileUTF8() *dfa.DFA {
+	NFA := nfa.New()
+	stateMappings := make(map[int]int)
+	for _, state := range symbolicDFA.States {
+		stateMappings[state] = NFA.AddState(intArray.IndexOf(state, symbolicDFA.StartStates) != -1, intArray.IndexOf(state, symbolicDFA.AcceptStates) != -1)
+	}
+	symbols := make([]string, 256)
+	for i := range symbols {
+		symbols[i] = string([]byte{byte(i)})
+	}
+	used := make([]bool, 256)
+	addBytes := func(sourceState int, bytes byteRange, targetState int) {
+		for b := int(bytes.lo); b <= int(bytes.hi); b++ {
+			NFA.AddTransition(sourceState, symbols[b], targetState)
+			used[b] = true
+		}
+	}
+	//states matching the rest of a sequence depend only on the byte ranges left
+	//and where they lead, so they are shared between all sequences
+	type suffix struct {
+		bytes       byteRange
+		targetState int
+	}
+	suffixStates := make(map[suffix]int)
+	for _, state := range symbolicDFA.States {
+		for _, transition := range symbolicDFA.Transitions[state] {
+			for _, current := range transition.Set {
+				for _, sequence := range utf8Sequences(current) {
+					targetState := stateMappings[transition.To]
+					for i := len(sequence) - 1; i > 0; i-- {
+						key := suffix{sequence[i], targetState}
+						suffixState, exists := suffixStates[key]
+						if !exists {
+							suffixState = NFA.AddState(false, false)
+							addBytes(suffixState, sequence[i], targetState)
+							suffixStates[key] = suffixState
+						}
+						targetState = suffixState
+					}
+					addBytes(stateMappings[state], sequence[0], targetState)
+				}
+			}
+		}
+	}
+	for b, isUsed := range used {
+		if isUsed {
+			NFA.Alphabet = append(NFA.Alphabet, symbols[b])
+		}
+	}
+	byteDFA := dfa.FromNFA(NFA)
+	byteDFA.MinimizeBrzozowski()
+	return byteDFA
+}
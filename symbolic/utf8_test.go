@@ -0,0 +1,104 @@
+package symbolic
+
+import (
+	"math/rand"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+func matchesSequence(sequence []byteRange, encoded []byte) bool {
+	if len(sequence) != len(encoded) {
+		return false
+	}
+	for i, bytes := range sequence {
+		if encoded[i] < bytes.lo || encoded[i] > bytes.hi {
+			return false
+		}
+	}
+	return true
+}
+
+//checkSequences checks every rune of current against the sequences, the encoding
+//of each rune other than a surrogate must be matched by exactly one sequence.
+//The sequences together must then match as many byte strings as there are
+//such runes, so they match nothing else
+func checkSequences(t *testing.T, current Range) {
+	t.Helper()
+	sequences := utf8Sequences(current)
+	matched := 0
+	for _, sequence := range sequences {
+		strings := 1
+		for _, bytes := range sequence {
+			strings *= int(bytes.hi) - int(bytes.lo) + 1
+		}
+		matched += strings
+	}
+	runes := 0
+	encoded := make([]byte, utf8.UTFMax)
+	for r := current.Lo; r <= current.Hi; r++ {
+		if r >= 0xD800 && r <= 0xDFFF {
+			continue
+		}
+		runes++
+		size := utf8.EncodeRune(encoded, r)
+		count := 0
+		for _, sequence := range sequences {
+			if matchesSequence(sequence, encoded[:size]) {
+				count++
+			}
+		}
+		if count != 1 {
+			t.Fatalf("%U-%U: %U matched by %d sequences", current.Lo, current.Hi, r, count)
+		}
+	}
+	if matched != runes {
+		t.Errorf("%U-%U: sequences match %d byte strings, expected %d", current.Lo, current.Hi, matched, runes)
+	}
+}
+
+func TestUTF8SequencesAllRunes(t *testing.T) {
+	checkSequences(t, Range{0, unicode.MaxRune})
+}
+
+func TestUTF8SequencesBoundaries(t *testing.T) {
+	for _, current := range []Range{
+		{0, 0x7F},
+		{0x7F, 0x80},
+		{0x80, 0x7FF},
+		{0x7FF, 0x800},
+		{0x800, 0xFFFF},
+		{0xD7FF, 0xE000},
+		{0xD800, 0xDFFF},
+		{0xDFFF, 0xDFFF},
+		{0xFFFF, 0x10000},
+		{0x10000, unicode.MaxRune},
+		{unicode.MaxRune, unicode.MaxRune},
+		{0x41, 0x40},
+	} {
+		checkSequences(t, current)
+	}
+	if sequences := utf8Sequences(Range{0xD800, 0xDFFF}); len(sequences) != 0 {
+		t.Errorf("surrogates: expected no sequences, got %v", sequences)
+	}
+}
+
+func TestUTF8SequencesRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	boundaries := []rune{0, 0x7F, 0x7FF, 0xD7FF, 0xDFFF, 0xFFFF, 0x3FFFF, unicode.MaxRune}
+	for i := 0; i < 300; i++ {
+		//ranges start near the points where encodings change length or bytes roll over
+		lo := boundaries[random.Intn(len(boundaries))] + rune(random.Intn(129)) - 64
+		if i%3 == 0 {
+			lo = rune(random.Intn(unicode.MaxRune + 1))
+		}
+		hi := lo + rune(random.Intn(5000))
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > unicode.MaxRune {
+			hi = unicode.MaxRune
+		}
+		checkSequences(t, Range{lo, hi})
+	}
+}